@@ -1,6 +1,9 @@
 package castlearn
 
-import "time"
+import (
+	"math/big"
+	"time"
+)
 
 // ToBool casts an interface to a bool type.
 func ToBool(i interface{}) bool {
@@ -19,6 +22,51 @@ func ToTimeInDefaultLocation(i interface{}, location *time.Location) time.Time {
 	return v
 }
 
+// ToUnix casts an interface to a Unix time in seconds.
+func ToUnix(i interface{}) int64 {
+	v, _ := ToUnixE(i)
+	return v
+}
+
+// ToUnixMilli casts an interface to a Unix time in milliseconds.
+func ToUnixMilli(i interface{}) int64 {
+	v, _ := ToUnixMilliE(i)
+	return v
+}
+
+// ToUnixNano casts an interface to a Unix time in nanoseconds.
+func ToUnixNano(i interface{}) int64 {
+	v, _ := ToUnixNanoE(i)
+	return v
+}
+
+// ToTimeString casts an interface to a time.Time and formats it with the
+// given layout.
+func ToTimeString(i interface{}, layout string) string {
+	v, _ := ToTimeStringE(i, layout)
+	return v
+}
+
+// ToTimeStringInDefaultLocation casts an interface to a time.Time and
+// formats it with the given layout, interpreting inputs without a timezone
+// to be in the given location.
+func ToTimeStringInDefaultLocation(i interface{}, layout string, location *time.Location) string {
+	v, _ := ToTimeStringInDefaultLocationE(i, layout, location)
+	return v
+}
+
+// ToDate casts an interface to a Date type.
+func ToDate(i interface{}) Date {
+	v, _ := ToDateE(i)
+	return v
+}
+
+// ToLocation casts an interface to a *time.Location type.
+func ToLocation(i interface{}) *time.Location {
+	v, _ := ToLocationE(i)
+	return v
+}
+
 // ToDuration casts an interface to a time.Duration type.
 func ToDuration(i interface{}) time.Duration {
 	v, _ := ToDurationE(i)
@@ -37,6 +85,36 @@ func ToFloat32(i interface{}) float32 {
 	return v
 }
 
+// ToComplex128 casts an interface to a complex128 type.
+func ToComplex128(i interface{}) complex128 {
+	v, _ := ToComplex128E(i)
+	return v
+}
+
+// ToComplex64 casts an interface to a complex64 type.
+func ToComplex64(i interface{}) complex64 {
+	v, _ := ToComplex64E(i)
+	return v
+}
+
+// ToRatio casts an interface to a fraction.
+func ToRatio(i interface{}) float64 {
+	v, _ := ToRatioE(i)
+	return v
+}
+
+// ToPercent casts an interface holding a percentage to a fraction.
+func ToPercent(i interface{}) float64 {
+	v, _ := ToPercentE(i)
+	return v
+}
+
+// ToUnitRatio casts an interface to a fraction in [0, 1].
+func ToUnitRatio(i interface{}) float64 {
+	v, _ := ToUnitRatioE(i)
+	return v
+}
+
 // ToInt64 casts an interface to an int64 type.
 func ToInt64(i interface{}) int64 {
 	v, _ := ToInt64E(i)
@@ -96,12 +174,50 @@ func ToUint8(i interface{}) uint8 {
 	return v
 }
 
+// ToBigInt casts an interface to a *big.Int type.
+func ToBigInt(i interface{}) *big.Int {
+	v, _ := ToBigIntE(i)
+	return v
+}
+
+// ToBigFloat casts an interface to a *big.Float type.
+func ToBigFloat(i interface{}) *big.Float {
+	v, _ := ToBigFloatE(i)
+	return v
+}
+
+// ToBigRat casts an interface to a *big.Rat type.
+func ToBigRat(i interface{}) *big.Rat {
+	v, _ := ToBigRatE(i)
+	return v
+}
+
+// ToDecimal casts an interface to a Decimal type.
+func ToDecimal(i interface{}) Decimal {
+	v, _ := ToDecimalE(i)
+	return v
+}
+
+// ToDecimalWithScale casts an interface to a Decimal type with the given
+// scale.
+func ToDecimalWithScale(i interface{}, scale int32, mode RoundingMode) Decimal {
+	v, _ := ToDecimalWithScaleE(i, scale, mode)
+	return v
+}
+
 // ToString casts an interface to a string type.
 func ToString(i interface{}) string {
 	v, _ := ToStringE(i)
 	return v
 }
 
+// ToStringWithFormat casts an interface to a string type, formatting floats
+// with the given FloatFormat.
+func ToStringWithFormat(i interface{}, format FloatFormat) string {
+	v, _ := ToStringWithFormatE(i, format)
+	return v
+}
+
 // ToStringMapString casts an interface to a map[string]string type.
 func ToStringMapString(i interface{}) map[string]string {
 	v, _ := ToStringMapStringE(i)
@@ -138,6 +254,13 @@ func ToStringMap(i interface{}) map[string]interface{} {
 	return v
 }
 
+// ToStringMapDeep casts an interface to a map[string]interface{} type,
+// converting nested maps and slices too.
+func ToStringMapDeep(i interface{}) map[string]interface{} {
+	v, _ := ToStringMapDeepE(i)
+	return v
+}
+
 // ToSlice casts an interface to a []interface{} type.
 func ToSlice(i interface{}) []interface{} {
 	v, _ := ToSliceE(i)
@@ -167,3 +290,40 @@ func ToDurationSlice(i interface{}) []time.Duration {
 	v, _ := ToDurationSliceE(i)
 	return v
 }
+
+// ToSliceOfStringMap casts an interface to a []map[string]interface{} type.
+func ToSliceOfStringMap(i interface{}) []map[string]interface{} {
+	v, _ := ToSliceOfStringMapE(i)
+	return v
+}
+
+// ToSliceOfStringMapString casts an interface to a []map[string]string type.
+func ToSliceOfStringMapString(i interface{}) []map[string]string {
+	v, _ := ToSliceOfStringMapStringE(i)
+	return v
+}
+
+// ToStringMapSlice casts an interface to a map[string][]interface{} type.
+func ToStringMapSlice(i interface{}) map[string][]interface{} {
+	v, _ := ToStringMapSliceE(i)
+	return v
+}
+
+// ToStringMapIntSlice casts an interface to a map[string][]int type.
+func ToStringMapIntSlice(i interface{}) map[string][]int {
+	v, _ := ToStringMapIntSliceE(i)
+	return v
+}
+
+// To casts an interface to the type T.
+func To[T any](i interface{}) T {
+	v, _ := ToE[T](i)
+	return v
+}
+
+// ToWithCaster casts an interface to the type T like To, with the options
+// of the given Caster.
+func ToWithCaster[T any](i interface{}, c Caster) T {
+	v, _ := ToWithCasterE[T](i, c)
+	return v
+}
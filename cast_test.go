@@ -5,7 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"html/template"
+	"math"
+	"math/big"
+	"net"
 	"path"
+	"strconv"
 	"testing"
 	"time"
 
@@ -40,7 +44,15 @@ func TestToUintE(t *testing.T) {
 		{"8", 8, false},
 		{jn, 8, false},
 		{nil, 0, false},
+		{big.NewInt(8), 8, false},
+		{big.NewFloat(8.31), 8, false},
+		{big.NewRat(17, 2), 8, false},
+		{complex(8.31, 0), 8, false},
+		{complex64(complex(8, 0)), 8, false},
 		// errors
+		{complex(8, 1), 0, true},
+		{big.NewInt(-8), 0, true},
+		{new(big.Int).Lsh(big.NewInt(1), 64), 0, true},
 		{int(-8), 0, true},
 		{int8(-8), 0, true},
 		{int16(-8), 0, true},
@@ -100,7 +112,16 @@ func TestToUint64E(t *testing.T) {
 		{"8", 8, false},
 		{jn, 8, false},
 		{nil, 0, false},
+		{big.NewInt(8), 8, false},
+		{new(big.Int).SetUint64(math.MaxUint64), math.MaxUint64, false},
+		{big.NewFloat(8.31), 8, false},
+		{big.NewRat(17, 2), 8, false},
+		{complex(8.31, 0), 8, false},
+		{complex64(complex(8, 0)), 8, false},
 		// errors
+		{complex(8, 1), 0, true},
+		{big.NewInt(-8), 0, true},
+		{new(big.Int).Lsh(big.NewInt(1), 64), 0, true},
 		{int(-8), 0, true},
 		{int8(-8), 0, true},
 		{int16(-8), 0, true},
@@ -161,7 +182,13 @@ func TestToUint32E(t *testing.T) {
 		{jn, 8, false},
 		{nil, 0, false},
 		{nj, 0, true},
+		{big.NewInt(8), 8, false},
+		{big.NewInt(math.MaxUint32), math.MaxUint32, false},
+		{complex(8.31, 0), 8, false},
+		{complex64(complex(8, 0)), 8, false},
 		// errors
+		{complex(8, 1), 0, true},
+		{big.NewInt(math.MaxUint32 + 1), 0, true},
 		{jne, 0, true},
 		{int(-8), 0, true},
 		{int8(-8), 0, true},
@@ -220,7 +247,13 @@ func TestToUint16E(t *testing.T) {
 		{"8", 8, false},
 		{jn, 8, false},
 		{nil, 0, false},
+		{big.NewInt(8), 8, false},
+		{big.NewInt(math.MaxUint16), math.MaxUint16, false},
+		{complex(8.31, 0), 8, false},
+		{complex64(complex(8, 0)), 8, false},
 		// errors
+		{complex(8, 1), 0, true},
+		{big.NewInt(math.MaxUint16 + 1), 0, true},
 		{int(-8), 0, true},
 		{int8(-8), 0, true},
 		{int16(-8), 0, true},
@@ -280,7 +313,14 @@ func TestToUint8E(t *testing.T) {
 		{"8", 8, false},
 		{jn, 8, false},
 		{nil, 0, false},
+		{big.NewInt(8), 8, false},
+		{big.NewRat(255, 1), 255, false},
+		{complex(8.31, 0), 8, false},
+		{complex64(complex(8, 0)), 8, false},
 		// errors
+		{complex(8, 1), 0, true},
+		{big.NewInt(256), 0, true},
+		{big.NewFloat(-0.5), 0, true},
 		{int(-8), 0, true},
 		{int8(-8), 0, true},
 		{int16(-8), 0, true},
@@ -313,6 +353,88 @@ func TestToUint8E(t *testing.T) {
 	}
 }
 
+func TestToUintNegativeErrors(t *testing.T) {
+	var nj json.Number
+	_ = json.Unmarshal([]byte("-8"), &nj)
+	inputs := []interface{}{
+		int(-8), int8(-8), int16(-8), int32(-8), int64(-8),
+		float32(-8.31), float64(-0.5), "-8", "-0x10", "-99999999999999999999", nj,
+		big.NewInt(-8), big.NewFloat(-0.5), big.NewRat(-1, 2),
+	}
+
+	for i, input := range inputs {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		_, err := ToUintE(input)
+		assert.True(t, errors.Is(err, ErrNegativeNotAllowed), errmsg)
+		_, err = ToUint64E(input)
+		assert.True(t, errors.Is(err, ErrNegativeNotAllowed), errmsg)
+		_, err = ToUint32E(input)
+		assert.True(t, errors.Is(err, ErrNegativeNotAllowed), errmsg)
+		_, err = ToUint16E(input)
+		assert.True(t, errors.Is(err, ErrNegativeNotAllowed), errmsg)
+		_, err = ToUint8E(input)
+		assert.True(t, errors.Is(err, ErrNegativeNotAllowed), errmsg)
+	}
+}
+
+func TestToUintRangeErrors(t *testing.T) {
+	var jn json.Number
+	_ = json.Unmarshal([]byte("18446744073709551616"), &jn)
+	tests := []struct {
+		input   interface{}
+		minBits int // smallest bit size the input fits in, 0 if none
+	}{
+		{int(255), 8},
+		{int(256), 16},
+		{int64(math.MaxInt64), 64},
+		{int32(70000), 32},
+		{uint(65536), 32},
+		{uint64(math.MaxUint32 + 1), 64},
+		{uint32(math.MaxUint16 + 1), 32},
+		{uint16(256), 16},
+		{float64(255.9), 8},
+		{float64(256), 16},
+		{float32(1e10), 64},
+		{float64(1e20), 0},
+		{"255", 8},
+		{"256", 16},
+		{"0x10000", 32},
+		{"18446744073709551615", 64},
+		{"18446744073709551616", 0},
+		{jn, 0},
+		{new(big.Int).SetUint64(math.MaxUint64), 64},
+		{new(big.Int).Lsh(big.NewInt(1), 64), 0},
+	}
+
+	casts := []struct {
+		bits int
+		cast func(interface{}) (uint64, error)
+	}{
+		{8, func(i interface{}) (uint64, error) { v, err := ToUint8E(i); return uint64(v), err }},
+		{16, func(i interface{}) (uint64, error) { v, err := ToUint16E(i); return uint64(v), err }},
+		{32, func(i interface{}) (uint64, error) { v, err := ToUint32E(i); return uint64(v), err }},
+		{64, ToUint64E},
+		{strconv.IntSize, func(i interface{}) (uint64, error) { v, err := ToUintE(i); return uint64(v), err }},
+	}
+
+	for i, test := range tests {
+		for _, c := range casts {
+			errmsg := fmt.Sprintf("i = %d, bits = %d", i, c.bits) // assert helper message
+
+			v, err := c.cast(test.input)
+			if test.minBits == 0 || c.bits < test.minBits {
+				assert.True(t, errors.Is(err, ErrValueOutOfRange), errmsg)
+				assert.Equal(t, uint64(0), v, errmsg)
+				continue
+			}
+
+			assert.NoError(t, err, errmsg)
+			assert.Equal(t, ToUint64(test.input), v, errmsg)
+		}
+	}
+}
+
 func TestToIntE(t *testing.T) {
 	var jn, nj json.Number
 	_ = json.Unmarshal([]byte("8"), &jn)
@@ -339,7 +461,14 @@ func TestToIntE(t *testing.T) {
 		{"8", 8, false},
 		{jn, 8, false},
 		{nil, 0, false},
+		{big.NewInt(8), 8, false},
+		{big.NewFloat(8.31), 8, false},
+		{big.NewRat(17, 2), 8, false},
+		{complex(8.31, 0), 8, false},
+		{complex64(complex(8, 0)), 8, false},
 		// errors
+		{complex(8, 1), 0, true},
+		{new(big.Int).Lsh(big.NewInt(1), 64), 0, true},
 		{"test", 0, true},
 		{nj, 0, true},
 		{testing.T{}, 0, true},
@@ -363,6 +492,31 @@ func TestToIntE(t *testing.T) {
 	}
 }
 
+func TestToIntNonFiniteErrors(t *testing.T) {
+	inputs := []interface{}{math.NaN(), math.Inf(1), math.Inf(-1), float32(math.NaN()), float32(math.Inf(1))}
+
+	for i, input := range inputs {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		_, err := ToIntE(input)
+		assert.True(t, errors.Is(err, ErrNotFinite), errmsg)
+		_, err = ToInt64E(input)
+		assert.True(t, errors.Is(err, ErrNotFinite), errmsg)
+		_, err = ToInt32E(input)
+		assert.True(t, errors.Is(err, ErrNotFinite), errmsg)
+		_, err = ToInt16E(input)
+		assert.True(t, errors.Is(err, ErrNotFinite), errmsg)
+		_, err = ToInt8E(input)
+		assert.True(t, errors.Is(err, ErrNotFinite), errmsg)
+		_, err = ToUintE(input)
+		assert.True(t, errors.Is(err, ErrNotFinite), errmsg)
+		_, err = ToUint64E(input)
+		assert.True(t, errors.Is(err, ErrNotFinite), errmsg)
+		_, err = ToUint8E(input)
+		assert.True(t, errors.Is(err, ErrNotFinite), errmsg)
+	}
+}
+
 func TestToInt64E(t *testing.T) {
 	var jn, nj json.Number
 	_ = json.Unmarshal([]byte("8"), &jn)
@@ -389,7 +543,17 @@ func TestToInt64E(t *testing.T) {
 		{"8", 8, false},
 		{jn, 8, false},
 		{nil, 0, false},
+		{big.NewInt(8), 8, false},
+		{big.NewInt(math.MinInt64), math.MinInt64, false},
+		{big.NewFloat(-8.31), -8, false},
+		{big.NewRat(-17, 2), -8, false},
+		{complex(8.31, 0), 8, false},
+		{complex64(complex(8, 0)), 8, false},
 		// errors
+		{complex(8, 1), 0, true},
+		{new(big.Int).Lsh(big.NewInt(1), 63), 0, true},
+		{new(big.Float).SetInf(false), 0, true},
+		{(*big.Int)(nil), 0, true},
 		{"test", 0, true},
 		{nj, 0, true},
 		{testing.T{}, 0, true},
@@ -439,7 +603,13 @@ func TestToInt32E(t *testing.T) {
 		{"8", 8, false},
 		{jn, 8, false},
 		{nil, 0, false},
+		{big.NewInt(8), 8, false},
+		{big.NewInt(math.MinInt32), math.MinInt32, false},
+		{complex(8.31, 0), 8, false},
+		{complex64(complex(8, 0)), 8, false},
 		// errors
+		{complex(8, 1), 0, true},
+		{big.NewInt(math.MaxInt32 + 1), 0, true},
 		{"test", 0, true},
 		{nj, 0, true},
 		{testing.T{}, 0, true},
@@ -489,7 +659,13 @@ func TestToInt16E(t *testing.T) {
 		{"8", 8, false},
 		{jn, 8, false},
 		{nil, 0, false},
+		{big.NewInt(8), 8, false},
+		{big.NewInt(math.MaxInt16), math.MaxInt16, false},
+		{complex(8.31, 0), 8, false},
+		{complex64(complex(8, 0)), 8, false},
 		// errors
+		{complex(8, 1), 0, true},
+		{big.NewInt(math.MaxInt16 + 1), 0, true},
 		{"test", 0, true},
 		{nj, 0, true},
 		{testing.T{}, 0, true},
@@ -539,7 +715,13 @@ func TestToInt8E(t *testing.T) {
 		{"8", 8, false},
 		{jn, 8, false},
 		{nil, 0, false},
+		{big.NewInt(8), 8, false},
+		{big.NewInt(math.MinInt8), math.MinInt8, false},
+		{complex(8.31, 0), 8, false},
+		{complex64(complex(8, 0)), 8, false},
 		// errors
+		{complex(8, 1), 0, true},
+		{big.NewInt(math.MinInt8 - 1), 0, true},
 		{"test", 0, true},
 		{nj, 0, true},
 		{testing.T{}, 0, true},
@@ -588,7 +770,14 @@ func TestToFloat64E(t *testing.T) {
 		{jn, 8, false},
 		{true, 1, false},
 		{false, 0, false},
+		{big.NewInt(8), 8, false},
+		{big.NewFloat(8.31), 8.31, false},
+		{big.NewRat(1, 4), 0.25, false},
+		{complex(8.31, 0), 8.31, false},
+		{complex64(complex(8, 0)), 8, false},
 		// errors
+		{complex(8, 1), 0, true},
+		{new(big.Int).Lsh(big.NewInt(1), 1024), 0, true},
 		{"test", 0, true},
 		{nj, 0, true},
 		{testing.T{}, 0, true},
@@ -637,7 +826,14 @@ func TestToFloat32E(t *testing.T) {
 		{jn, 8, false},
 		{true, 1, false},
 		{false, 0, false},
+		{big.NewInt(8), 8, false},
+		{big.NewFloat(8.31), 8.31, false},
+		{big.NewRat(1, 4), 0.25, false},
+		{complex(8.31, 0), 8.31, false},
+		{complex64(complex(8, 0)), 8, false},
 		// errors
+		{complex(8, 1), 0, true},
+		{big.NewFloat(math.MaxFloat64), 0, true},
 		{"test", 0, true},
 		{nj, 0, true},
 		{testing.T{}, 0, true},
@@ -661,6 +857,408 @@ func TestToFloat32E(t *testing.T) {
 	}
 }
 
+func TestToBigIntE(t *testing.T) {
+	var jn, jnl, nj json.Number
+	_ = json.Unmarshal([]byte("8"), &jn)
+	_ = json.Unmarshal([]byte("123456789012345678901234567890"), &jnl)
+	_ = json.Unmarshal([]byte("8.0"), &nj)
+	large, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	tests := []struct {
+		input  interface{}
+		expect *big.Int
+		iserr  bool
+	}{
+		{int(8), big.NewInt(8), false},
+		{int8(-8), big.NewInt(-8), false},
+		{int64(math.MinInt64), big.NewInt(math.MinInt64), false},
+		{uint64(math.MaxUint64), new(big.Int).SetUint64(math.MaxUint64), false},
+		{uint8(8), big.NewInt(8), false},
+		{float32(8.31), big.NewInt(8), false},
+		{float64(-8.31), big.NewInt(-8), false},
+		{float64(1e20), new(big.Int).Mul(big.NewInt(1e10), big.NewInt(1e10)), false},
+		{"123456789012345678901234567890", large, false},
+		{"0x10", big.NewInt(16), false},
+		{jn, big.NewInt(8), false},
+		{jnl, large, false},
+		{large, large, false},
+		{big.NewFloat(8.31), big.NewInt(8), false},
+		{big.NewRat(-17, 2), big.NewInt(-8), false},
+		{true, big.NewInt(1), false},
+		{false, big.NewInt(0), false},
+		{nil, big.NewInt(0), false},
+		{"8.31", big.NewInt(8), false},
+		{"-8.31", big.NewInt(-8), false},
+		{"1e3", big.NewInt(1000), false},
+		{"17/2", big.NewInt(8), false},
+		{nj, big.NewInt(8), false},
+		// errors
+		{"test", nil, true},
+		{"1/0", nil, true},
+		{math.NaN(), nil, true},
+		{math.Inf(1), nil, true},
+		{new(big.Float).SetInf(true), nil, true},
+		{testing.T{}, nil, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := ToBigIntE(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, 0, test.expect.Cmp(v), errmsg)
+
+		// Non-E test
+		v = ToBigInt(test.input)
+		assert.Equal(t, 0, test.expect.Cmp(v), errmsg)
+	}
+
+	// The result does not alias the input.
+	v := ToBigInt(large)
+	v.SetInt64(0)
+	assert.Equal(t, "123456789012345678901234567890", large.String())
+}
+
+func TestToBigFloatE(t *testing.T) {
+	var jn, nj json.Number
+	_ = json.Unmarshal([]byte("0.1"), &jn)
+	_ = json.Unmarshal([]byte("test"), &nj)
+	tests := []struct {
+		input  interface{}
+		expect string
+		iserr  bool
+	}{
+		{int(8), "8", false},
+		{int64(math.MinInt64), "-9223372036854775808", false},
+		{uint64(math.MaxUint64), "18446744073709551615", false},
+		{float32(0.5), "0.5", false},
+		{float64(8.31), "8.31", false},
+		{math.Inf(-1), "-Inf", false},
+		{"123456789012345678901234567890.5", "123456789012345678901234567890.5", false},
+		{"0.1", "0.1", false},
+		{"1e3", "1000", false},
+		{jn, "0.1", false},
+		{big.NewInt(8), "8", false},
+		{big.NewRat(1, 4), "0.25", false},
+		{big.NewFloat(8.31), "8.31", false},
+		{true, "1", false},
+		{false, "0", false},
+		{nil, "0", false},
+		// errors
+		{"test", "", true},
+		{nj, "", true},
+		{math.NaN(), "", true},
+		{testing.T{}, "", true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := ToBigFloatE(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, ToString(v), errmsg)
+
+		// Non-E test
+		v = ToBigFloat(test.input)
+		assert.Equal(t, test.expect, ToString(v), errmsg)
+	}
+}
+
+func TestToBigRatE(t *testing.T) {
+	var jn json.Number
+	_ = json.Unmarshal([]byte("19.99"), &jn)
+	tests := []struct {
+		input  interface{}
+		expect string
+		iserr  bool
+	}{
+		{int(8), "8", false},
+		{int64(math.MinInt64), "-9223372036854775808", false},
+		{uint64(math.MaxUint64), "18446744073709551615", false},
+		{float64(0.25), "1/4", false},
+		{float32(0.5), "1/2", false},
+		{"19.99", "1999/100", false},
+		{"3/4", "3/4", false},
+		{"1e-3", "1/1000", false},
+		{jn, "1999/100", false},
+		{big.NewInt(8), "8", false},
+		{big.NewFloat(0.5), "1/2", false},
+		{big.NewRat(6, 8), "3/4", false},
+		{true, "1", false},
+		{false, "0", false},
+		{nil, "0", false},
+		// errors
+		{"test", "", true},
+		{math.NaN(), "", true},
+		{math.Inf(1), "", true},
+		{new(big.Float).SetInf(false), "", true},
+		{testing.T{}, "", true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := ToBigRatE(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, ToString(v), errmsg)
+
+		// Non-E test
+		v = ToBigRat(test.input)
+		assert.Equal(t, test.expect, ToString(v), errmsg)
+	}
+}
+
+func TestToDecimalE(t *testing.T) {
+	var jn, nj json.Number
+	_ = json.Unmarshal([]byte("19.99"), &jn)
+	_ = json.Unmarshal([]byte("test"), &nj)
+	tests := []struct {
+		input  interface{}
+		expect string
+		iserr  bool
+	}{
+		{int(8), "8", false},
+		{int64(math.MinInt64), "-9223372036854775808", false},
+		{uint64(math.MaxUint64), "18446744073709551615", false},
+		{float64(19.99), "19.99", false},
+		{float32(19.99), "19.99", false},
+		{float64(1e21), "1000000000000000000000", false},
+		{float64(1e-7), "0.0000001", false},
+		{"19.99", "19.99", false},
+		{"-0.10", "-0.10", false},
+		{jn, "19.99", false},
+		{big.NewInt(8), "8", false},
+		{big.NewRat(1, 8), "0.125", false},
+		{big.NewFloat(0.5), "0.5", false},
+		{NewDecimal(1999, 2), "19.99", false},
+		{true, "1", false},
+		{false, "0", false},
+		{nil, "0", false},
+		// errors
+		{"test", "", true},
+		{nj, "", true},
+		{big.NewRat(1, 3), "", true},
+		{math.NaN(), "", true},
+		{math.Inf(1), "", true},
+		{testing.T{}, "", true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := ToDecimalE(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, ToString(v), errmsg)
+
+		// Non-E test
+		v = ToDecimal(test.input)
+		assert.Equal(t, test.expect, ToString(v), errmsg)
+	}
+}
+
+func TestToDecimalWithScaleE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		scale  int32
+		mode   RoundingMode
+		expect string
+		iserr  bool
+	}{
+		{"19.999", 2, RoundHalfUp, "20.00", false},
+		{"19.995", 2, RoundHalfEven, "20.00", false},
+		{"19.985", 2, RoundHalfEven, "19.98", false},
+		{float64(2.675), 2, RoundHalfUp, "2.68", false},
+		{int(5), 2, RoundHalfUp, "5.00", false},
+		{big.NewRat(1, 3), 4, RoundHalfUp, "0.3333", false},
+		{big.NewRat(-2, 3), 2, RoundDown, "-0.66", false},
+		// errors
+		{"test", 2, RoundHalfUp, "", true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := ToDecimalWithScaleE(test.input, test.scale, test.mode)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, ToString(v), errmsg)
+
+		// Non-E test
+		v = ToDecimalWithScale(test.input, test.scale, test.mode)
+		assert.Equal(t, test.expect, ToString(v), errmsg)
+	}
+}
+
+func TestToComplex128E(t *testing.T) {
+	var jn json.Number
+	_ = json.Unmarshal([]byte("8.5"), &jn)
+	tests := []struct {
+		input  interface{}
+		expect complex128
+		iserr  bool
+	}{
+		{complex(1, 2), complex(1, 2), false},
+		{complex64(complex(1, -2)), complex(1, -2), false},
+		{int(8), 8, false},
+		{uint8(8), 8, false},
+		{float64(8.31), 8.31, false},
+		{"1+2i", complex(1, 2), false},
+		{"(1.5-2e3i)", complex(1.5, -2000), false},
+		{"3i", complex(0, 3), false},
+		{"8", 8, false},
+		{jn, 8.5, false},
+		{big.NewRat(1, 4), 0.25, false},
+		{true, 1, false},
+		{nil, 0, false},
+		// errors
+		{"1+2j", 0, true},
+		{"test", 0, true},
+		{testing.T{}, 0, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := ToComplex128E(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+
+		// Non-E test
+		v = ToComplex128(test.input)
+		assert.Equal(t, test.expect, v, errmsg)
+
+		assert.Equal(t, complex64(test.expect), ToComplex64(test.input), errmsg)
+	}
+
+	for _, input := range []interface{}{complex(1, 1), complex64(complex(0, -1))} {
+		_, err := ToIntE(input)
+		assert.True(t, errors.Is(err, ErrNonZeroImaginary))
+		_, err = ToUint8E(input)
+		assert.True(t, errors.Is(err, ErrNonZeroImaginary))
+		_, err = ToFloat64E(input)
+		assert.True(t, errors.Is(err, ErrNonZeroImaginary))
+	}
+
+	assert.Equal(t, "(1+2i)", ToString(complex(1, 2)))
+	assert.Equal(t, "(1.5-0.25i)", ToString(complex64(complex(1.5, -0.25))))
+	assert.Equal(t, "(1.5e+00-2e-01i)", ToStringWithFormat(complex(1.5, -0.2), FloatFormat{Verb: 'e', Precision: 2, TrimZeros: true}))
+}
+
+func TestToRatioE(t *testing.T) {
+	var jn json.Number
+	_ = json.Unmarshal([]byte("0.75"), &jn)
+	tests := []struct {
+		input         interface{}
+		expect        float64
+		expectPercent float64
+		iserr         bool
+	}{
+		{"75%", 0.75, 0.75, false},
+		{" 12.5 % ", 0.125, 0.125, false},
+		{"150%", 1.5, 1.5, false},
+		{"3/4", 0.75, 0.75, false},
+		{"1 / 8", 0.125, 0.125, false},
+		{"0.75", 0.75, 0.0075, false},
+		{"75", 75, 0.75, false},
+		{float64(0.75), 0.75, 0.0075, false},
+		{float32(0.5), 0.5, 0.005, false},
+		{int(1), 1, 0.01, false},
+		{jn, 0.75, 0.0075, false},
+		// errors
+		{"75 percent", 0, 0, true},
+		{"3/0", 0, 0, true},
+		{"3/", 0, 0, true},
+		{"%", 0, 0, true},
+		{"NaN", 0, 0, true},
+		{math.Inf(1), 0, 0, true},
+		{testing.T{}, 0, 0, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := ToRatioE(test.input)
+		p, errPercent := ToPercentE(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			assert.Error(t, errPercent, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.NoError(t, errPercent, errmsg)
+		assert.InDelta(t, test.expect, v, 1e-12, errmsg)
+		assert.InDelta(t, test.expectPercent, p, 1e-12, errmsg)
+
+		// Non-E test
+		assert.InDelta(t, test.expect, ToRatio(test.input), 1e-12, errmsg)
+		assert.InDelta(t, test.expectPercent, ToPercent(test.input), 1e-12, errmsg)
+	}
+}
+
+func TestToUnitRatioE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		expect float64
+		iserr  bool
+	}{
+		{"0%", 0, false},
+		{"100%", 1, false},
+		{"3/4", 0.75, false},
+		{0.5, 0.5, false},
+		// errors
+		{"150%", 0, true},
+		{"-1/4", 0, true},
+		{-0.5, 0, true},
+		{"test", 0, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := ToUnitRatioE(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+
+		// Non-E test
+		v = ToUnitRatio(test.input)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+}
+
 func TestToStringE(t *testing.T) {
 	var jn json.Number
 	_ = json.Unmarshal([]byte("8"), &jn)
@@ -857,6 +1455,89 @@ func TestToStringMapE(t *testing.T) {
 	}
 }
 
+func TestJSONOptions(t *testing.T) {
+	const doc = `{"id": 9007199254740993, "ratio": 0.5}`
+
+	m := ToStringMap(doc)
+	assert.Equal(t, float64(9007199254740992), m["id"])
+	_, err := ToStringMapE(doc + ` {}`)
+	assert.Error(t, err)
+
+	SetJSONOptions(JSONOptions{UseNumber: true})
+	defer SetJSONOptions(JSONOptions{})
+
+	m = ToStringMap(doc)
+	assert.Equal(t, json.Number("9007199254740993"), m["id"])
+	assert.Equal(t, int64(9007199254740993), ToInt64(m["id"]))
+	assert.Equal(t, 0.5, ToFloat64(m["ratio"]))
+	assert.Equal(t, []interface{}{json.Number("1"), "a"}, ToSlice(`[1, "a"]`))
+
+	_, err = ToStringMapE(doc + ` {"trailing": true}`)
+	assert.Error(t, err)
+	_, err = ToStringMapIntE(`{"a": 1}]`)
+	assert.Error(t, err)
+	assert.Equal(t, map[string]int{"a": 1}, ToStringMapInt(` {"a": 1} `))
+
+	SetJSONOptions(JSONOptions{UseNumber: true, AllowTrailingData: true})
+	m, err = ToStringMapE(doc + ` {"trailing": true}`)
+	assert.NoError(t, err)
+	assert.Equal(t, json.Number("9007199254740993"), m["id"])
+}
+
+func TestToStringMapDeepE(t *testing.T) {
+	input := map[interface{}]interface{}{
+		"name": "api",
+		1:      true,
+		"servers": []interface{}{
+			map[interface{}]interface{}{"host": "a", "ports": []int{80, 443}},
+		},
+		"tls":  map[string]interface{}{"certs": [1][]byte{[]byte("pem")}},
+		"none": nil,
+	}
+	expect := map[string]interface{}{
+		"name": "api",
+		"1":    true,
+		"servers": []interface{}{
+			map[string]interface{}{"host": "a", "ports": []interface{}{80, 443}},
+		},
+		"tls":  map[string]interface{}{"certs": []interface{}{[]byte("pem")}},
+		"none": nil,
+	}
+
+	v, err := ToStringMapDeepE(input)
+	assert.NoError(t, err)
+	assert.Equal(t, expect, v)
+	assert.Equal(t, expect, ToStringMapDeep(&input))
+
+	v, err = ToStringMapDeepE(`{"a": [{"b": 1}]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": []interface{}{map[string]interface{}{"b": float64(1)}}}, v)
+
+	// A value shared by two keys is not a cycle.
+	shared := map[interface{}]interface{}{"x": 1}
+	v, err = ToStringMapDeepE(map[string]interface{}{"a": shared, "b": shared})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"x": 1}, v["b"])
+
+	cyclic := map[interface{}]interface{}{}
+	cyclic["list"] = []interface{}{cyclic}
+	_, err = ToStringMapDeepE(map[string]interface{}{"root": cyclic})
+	assert.True(t, errors.Is(err, ErrCycle))
+	assert.Contains(t, err.Error(), "$.root.list[0]")
+
+	_, err = ToStringMapDeepE(map[string]interface{}{
+		"a": []interface{}{map[interface{}]interface{}{struct{ X int }{1}: 1}},
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "$.a[0]")
+	}
+
+	_, err = ToStringMapDeepE([]interface{}{1})
+	assert.Error(t, err)
+	_, err = ToStringMapDeepE(nil)
+	assert.Error(t, err)
+}
+
 func TestToStringMapBoolE(t *testing.T) {
 	tests := []struct {
 		input  interface{}
@@ -1022,7 +1703,10 @@ func TestToBoolSliceE(t *testing.T) {
 		{[]interface{}{true, false, true}, []bool{true, false, true}, false},
 		{[]int{1, 0, 1}, []bool{true, false, true}, false},
 		{[]string{"true", "false", "true"}, []bool{true, false, true}, false},
+		{"true false", []bool{true, false}, false},
+		{"[true, 0, \"false\"]", []bool{true, false, false}, false},
 		// errors
+		{"[true, \"foo\"]", nil, true},
 		{nil, nil, true},
 		{testing.T{}, nil, true},
 		{[]string{"foo", "bar"}, nil, true},
@@ -1056,7 +1740,13 @@ func TestToIntSliceE(t *testing.T) {
 		{[]interface{}{1.2, 3.2}, []int{1, 3}, false},
 		{[]string{"2", "3"}, []int{2, 3}, false},
 		{[2]string{"2", "3"}, []int{2, 3}, false},
+		{"1 2", []int{1, 2}, false},
+		{" [1, 2, \"3\"] ", []int{1, 2, 3}, false},
+		{"[]", []int{}, false},
 		// errors
+		{"[1.5]", nil, true},
+		{"[1, {}]", nil, true},
+		{"1,2", nil, true},
 		{nil, nil, true},
 		{testing.T{}, nil, true},
 		{[]string{"foo", "bar"}, nil, true},
@@ -1088,9 +1778,13 @@ func TestToSliceE(t *testing.T) {
 	}{
 		{[]interface{}{1, 3}, []interface{}{1, 3}, false},
 		{[]map[string]interface{}{{"k1": 1}, {"k2": 2}}, []interface{}{map[string]interface{}{"k1": 1}, map[string]interface{}{"k2": 2}}, false},
+		{"a b", []interface{}{"a", "b"}, false},
+		{`[1, "a", [true]]`, []interface{}{json.Number("1"), "a", []interface{}{true}}, false},
 		// errors
 		{nil, nil, true},
 		{testing.T{}, nil, true},
+		{[]byte("ab"), nil, true},
+		{[]int{1, 2}, nil, true},
 	}
 
 	for i, test := range tests {
@@ -1127,6 +1821,9 @@ func TestToStringSliceE(t *testing.T) {
 		{[]interface{}{1, 3}, []string{"1", "3"}, false},
 		{interface{}(1), []string{"1"}, false},
 		{[]error{errors.New("a"), errors.New("b")}, []string{"a", "b"}, false},
+		{"a b", []string{"a", "b"}, false},
+		{`["a b", 1.50, true]`, []string{"a b", "1.50", "true"}, false},
+		{"[a b]", []string{"[a", "b]"}, false},
 		// errors
 		{nil, nil, true},
 		{testing.T{}, nil, true},
@@ -1160,7 +1857,10 @@ func TestToDurationSliceE(t *testing.T) {
 		{[]int{1, 2}, []time.Duration{1, 2}, false},
 		{[]interface{}{1, 3}, []time.Duration{1, 3}, false},
 		{[]time.Duration{1, 3}, []time.Duration{1, 3}, false},
+		{"1s 1m", []time.Duration{time.Second, time.Minute}, false},
+		{`["1s", 60000000000]`, []time.Duration{time.Second, time.Minute}, false},
 		// errors
+		{`["1s", "x"]`, nil, true},
 		{nil, nil, true},
 		{testing.T{}, nil, true},
 		{[]string{"invalid"}, nil, true},
@@ -1184,6 +1884,84 @@ func TestToDurationSliceE(t *testing.T) {
 	}
 }
 
+func TestToSliceOfStringMapE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		expect []map[string]interface{}
+		iserr  bool
+	}{
+		{[]map[string]interface{}{{"a": 1}}, []map[string]interface{}{{"a": 1}}, false},
+		{[]interface{}{map[interface{}]interface{}{"a": 1}, map[string]interface{}{"b": 2}}, []map[string]interface{}{{"a": 1}, {"b": 2}}, false},
+		{[1]map[string]interface{}{{"a": 1}}, []map[string]interface{}{{"a": 1}}, false},
+		{`[{"a": "x"}, {"b": true}]`, []map[string]interface{}{{"a": "x"}, {"b": true}}, false},
+		{"a=1 b=2", []map[string]interface{}{{"a": "1"}, {"b": "2"}}, false},
+		// errors
+		{nil, nil, true},
+		{testing.T{}, nil, true},
+		{[]interface{}{map[string]interface{}{}, 1}, nil, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := ToSliceOfStringMapE(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+
+		// Non-E test
+		v = ToSliceOfStringMap(test.input)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+}
+
+func TestToCompoundE(t *testing.T) {
+	assert.Equal(t,
+		[]map[string]string{{"host": "a", "port": "80"}, {"host": "b"}},
+		ToSliceOfStringMapString([]interface{}{
+			map[interface{}]interface{}{"host": "a", "port": 80},
+			map[string]interface{}{"host": "b"},
+		}))
+	assert.Equal(t,
+		map[string][]interface{}{"a": {1, "x"}, "b": {"y"}},
+		ToStringMapSlice(map[interface{}]interface{}{"a": []interface{}{1, "x"}, "b": []string{"y"}}))
+	assert.Equal(t,
+		map[string][]int{"web": {80, 443}, "db": {5432}},
+		ToStringMapIntSlice(map[string]interface{}{"web": []interface{}{"80", 443.0}, "db": []int{5432}}))
+	assert.Equal(t,
+		map[string][]int{"web": {80, 443}},
+		ToStringMapIntSlice(`{"web": [80, 443]}`))
+
+	_, err := ToSliceOfStringMapStringE([]interface{}{map[string]string{}, "x"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "index 1")
+	}
+	_, err = ToStringMapIntSliceE(map[string]interface{}{"web": []string{"80", "http"}})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `key "web": unable to cast []string{"80", "http"} of type []string to []int: index 1`)
+	}
+	_, err = ToStringMapSliceE(map[interface{}]interface{}{struct{ X int }{1}: []int{1}})
+	assert.Error(t, err)
+	_, err = ToStringMapSliceE(1)
+	assert.Error(t, err)
+
+	// Slices and arrays of any type but bytes.
+	assert.Equal(t,
+		[]map[string]interface{}{{"a": 1}},
+		ToSliceOfStringMap([1]map[string]interface{}{{"a": 1}}))
+	assert.Equal(t,
+		map[string][]interface{}{"a": {1, 2}},
+		ToStringMapSlice(map[string][2]int{"a": {1, 2}}))
+	_, err = ToStringMapSliceE(map[string][]byte{"a": []byte("ab")})
+	assert.Error(t, err)
+	_, err = ToSliceOfStringMapE([]byte("ab"))
+	assert.Error(t, err)
+}
+
 func TestToBoolE(t *testing.T) {
 	var jf, jt, je json.Number
 	_ = json.Unmarshal([]byte("0"), &jf)
@@ -1296,6 +2074,161 @@ func TestIndirectPointers(t *testing.T) {
 	assert.Equal(t, ToInt(z), 13)
 }
 
+func TestBytesInput(t *testing.T) {
+	assert.Equal(t, 42, ToInt([]byte("42")))
+	assert.Equal(t, int8(-8), ToInt8([]byte("-8")))
+	assert.Equal(t, uint32(42), ToUint32([]byte("42")))
+	assert.Equal(t, 8.5, ToFloat64([]byte("8.5")))
+	assert.Equal(t, float32(8.5), ToFloat32(json.RawMessage("8.5")))
+	assert.Equal(t, true, ToBool([]byte("true")))
+	assert.Equal(t, true, ToBool(json.RawMessage("true")))
+	assert.Equal(t, 90*time.Second, ToDuration([]byte("1m30s")))
+	assert.Equal(t, complex(1, 2), ToComplex128([]byte("1+2i")))
+	assert.Equal(t, big.NewInt(42), ToBigInt([]byte("42")))
+	assert.Equal(t, 0.75, ToRatio([]byte("75%")))
+	assert.Equal(t, "1.50", ToDecimal([]byte("1.50")).String())
+	assert.Equal(t, "abc", ToString(json.RawMessage(`"abc"`)))
+	assert.Equal(t, `{"a":1}`, ToString(json.RawMessage(`{"a":1}`)))
+	assert.Equal(t, 42, ToInt(json.RawMessage(`"42"`)))
+
+	assert.Equal(t, time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC), ToTime([]byte("2006-01-02T15:04:05Z")))
+
+	assert.Equal(t, Date{2006, time.January, 2}, ToDate(json.RawMessage(`"2006-01-02"`)))
+	assert.Equal(t, time.UTC, ToLocation([]byte("UTC")))
+
+	_, err := ToIntE([]byte("abc"))
+	assert.Error(t, err)
+	_, err = ToUintE([]byte("-1"))
+	assert.True(t, errors.Is(err, ErrNegativeNotAllowed))
+	_, err = ToBoolE(json.RawMessage("{}"))
+	assert.Error(t, err)
+
+	// Errors do not share the memory of a reused buffer.
+	buf := []byte("maybe")
+	_, err = ToBoolE(buf)
+	msg := err.Error()
+	copy(buf, "xxxxx")
+	assert.Equal(t, msg, err.Error())
+}
+
+type (
+	port    int
+	level   string
+	enabled bool
+	weight  float32
+	mask    uint8
+)
+
+func TestDefinedTypes(t *testing.T) {
+	p := port(8080)
+	assert.Equal(t, 8080, ToInt(p))
+	assert.Equal(t, int64(8080), ToInt64(&p))
+	assert.Equal(t, uint16(8080), ToUint16(p))
+	assert.Equal(t, float64(8080), ToFloat64(p))
+	assert.Equal(t, "8080", ToString(p))
+	assert.Equal(t, true, ToBool(p))
+	assert.Equal(t, 8*time.Second, ToDuration(level("8s")))
+	assert.Equal(t, "debug", ToString(level("debug")))
+	assert.Equal(t, 42, ToInt(level("42")))
+	assert.Equal(t, true, ToBool(enabled(true)))
+	assert.Equal(t, "true", ToString(enabled(true)))
+	assert.Equal(t, 1.5, ToFloat64(weight(1.5)))
+	assert.Equal(t, "1.5", ToString(weight(1.5)))
+	assert.Equal(t, uint(255), ToUint(mask(255)))
+	assert.Equal(t, 0, NewDecimal(25, 1).Cmp(ToDecimal(level("2.5"))))
+	assert.Equal(t, big.NewInt(8080), ToBigInt(p))
+
+	_, err := ToUintE(port(-1))
+	assert.True(t, errors.Is(err, ErrNegativeNotAllowed))
+	_, err = ToIntE(level("high"))
+	assert.Error(t, err)
+}
+
+func TestToE(t *testing.T) {
+	v, err := ToE[port]("8080")
+	assert.NoError(t, err)
+	assert.Equal(t, port(8080), v)
+
+	l, err := ToE[level](42)
+	assert.NoError(t, err)
+	assert.Equal(t, level("42"), l)
+
+	assert.Equal(t, port(1), To[port](true))
+	assert.Equal(t, enabled(true), To[enabled]("true"))
+	assert.Equal(t, weight(0.5), To[weight]("0.5"))
+	assert.Equal(t, mask(3), To[mask](port(3)))
+	assert.Equal(t, 90*time.Second, To[time.Duration]("1m30s"))
+	assert.Equal(t, time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC), To[time.Time]("2006-01-02"))
+	assert.Equal(t, Date{2006, time.January, 2}, To[Date]("2006-01-02"))
+	assert.Equal(t, big.NewInt(12), To[*big.Int]("12"))
+	assert.Equal(t, 12, To[interface{}](12))
+	assert.Equal(t, "x", To[fmt.Stringer](foo{"x"}).String())
+	assert.Nil(t, To[interface{}](nil))
+	assert.Nil(t, To[fmt.Stringer](nil))
+	assert.Nil(t, To[*int](nil))
+
+	e, err := ToE[error](nil)
+	assert.NoError(t, err)
+	assert.Nil(t, e)
+
+	_, err = ToE[mask](256)
+	assert.True(t, errors.Is(err, ErrValueOutOfRange))
+	_, err = ToE[port]("high")
+	assert.Error(t, err)
+	_, err = ToE[[]int]("1")
+	assert.Error(t, err)
+	_, err = ToE[fmt.Stringer](12)
+	assert.Error(t, err)
+}
+
+type priority int
+
+var priorityNames = []string{"low", "high"}
+
+func (p priority) MarshalText() ([]byte, error) {
+	if p < 0 || int(p) >= len(priorityNames) {
+		return nil, fmt.Errorf("invalid priority %d", int(p))
+	}
+	return []byte(priorityNames[p]), nil
+}
+
+func (p *priority) UnmarshalText(text []byte) error {
+	for i, name := range priorityNames {
+		if name == string(text) {
+			*p = priority(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid priority %q", text)
+}
+
+func TestTextMarshaler(t *testing.T) {
+	p := priority(1)
+	assert.Equal(t, "high", ToString(p))
+	assert.Equal(t, "high", ToString(&p))
+	assert.Equal(t, []string{"low", "high"}, ToStringSlice([]interface{}{priority(0), &p}))
+	_, err := ToStringE(priority(5))
+	assert.Error(t, err)
+
+	// Stringer still takes precedence.
+	assert.Equal(t, "2006-01-02", ToString(Date{2006, time.January, 2}))
+	assert.Equal(t, "::1", ToString(net.IPv6loopback))
+
+	v, err := ToE[priority]("high")
+	assert.NoError(t, err)
+	assert.Equal(t, priority(1), v)
+	assert.Equal(t, priority(1), To[priority]([]byte("high")))
+	assert.Equal(t, priority(1), To[priority](level("high")))
+	assert.Equal(t, priority(1), To[priority](1))
+	assert.Equal(t, &p, To[*priority]("high"))
+	_, err = ToE[priority]("urgent")
+	assert.Error(t, err)
+
+	assert.Equal(t, net.IPv4(10, 0, 0, 1), To[net.IP]("10.0.0.1"))
+	_, err = ToE[net.IP]("10.0.0.256")
+	assert.Error(t, err)
+}
+
 func TestToTime(t *testing.T) {
 	var jntime, jnetime json.Number
 	_ = json.Unmarshal([]byte("1234567890"), &jntime)
@@ -1339,10 +2272,27 @@ func TestToTime(t *testing.T) {
 		{uint(1482597504), time.Date(2016, 12, 24, 16, 38, 24, 0, time.UTC), false},
 		{uint64(1234567890), time.Date(2009, 2, 13, 23, 31, 30, 0, time.UTC), false},
 		{uint32(1234567890), time.Date(2009, 2, 13, 23, 31, 30, 0, time.UTC), false},
+		{int16(1234), time.Date(1970, 1, 1, 0, 20, 34, 0, time.UTC), false},
+		{int8(123), time.Date(1970, 1, 1, 0, 2, 3, 0, time.UTC), false},
+		{uint16(1234), time.Date(1970, 1, 1, 0, 20, 34, 0, time.UTC), false},
+		{uint8(123), time.Date(1970, 1, 1, 0, 2, 3, 0, time.UTC), false},
+		{float64(1700000000.123), time.Date(2023, 11, 14, 22, 13, 20, 123000000, time.UTC), false},
+		{float64(-1.5), time.Date(1969, 12, 31, 23, 59, 58, 500000000, time.UTC), false},
+		{float32(1048576.5), time.Date(1970, 1, 13, 3, 16, 16, 500000000, time.UTC), false},
+		{time.Duration(1234567890123456789), time.Date(2009, 2, 13, 23, 31, 30, 123456789, time.UTC), false},
+		{"1700000000", time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC), false},
+		{"1700000000.123456", time.Date(2023, 11, 14, 22, 13, 20, 123456000, time.UTC), false},
+		{"1.7e9", time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC), false},
+		{jnetime, time.Date(1970, 1, 1, 0, 2, 3, 456789000, time.UTC), false},
 		{time.Date(2009, 2, 13, 23, 31, 30, 0, time.UTC), time.Date(2009, 2, 13, 23, 31, 30, 0, time.UTC), false},
 		// errors
 		{"2006", time.Time{}, true},
-		{jnetime, time.Time{}, true},
+		{"20060102", time.Time{}, true},
+		{"2006-13", time.Time{}, true},
+		{"NaN", time.Time{}, true},
+		{math.Inf(1), time.Time{}, true},
+		{1e30, time.Time{}, true},
+		{"-1e30", time.Time{}, true},
 		{testing.T{}, time.Time{}, true},
 	}
 
@@ -1364,6 +2314,242 @@ func TestToTime(t *testing.T) {
 	}
 }
 
+func TestToUnixE(t *testing.T) {
+	var jn json.Number
+	_ = json.Unmarshal([]byte("1234567890"), &jn)
+	tests := []struct {
+		input       interface{}
+		expect      int64
+		expectMilli int64
+		expectNano  int64
+		iserr       bool
+	}{
+		{int64(1234567890), 1234567890, 1234567890000, 1234567890000000000, false},
+		{jn, 1234567890, 1234567890000, 1234567890000000000, false},
+		{"2009-02-13T23:31:30Z", 1234567890, 1234567890000, 1234567890000000000, false},
+		{"2009-02-13 23:31:30", 1234567890, 1234567890000, 1234567890000000000, false},
+		{"2009-02-13T23:31:30.123456789Z", 1234567890, 1234567890123, 1234567890123456789, false},
+		{time.Date(2009, 2, 13, 23, 31, 30, 5e6, time.UTC), 1234567890, 1234567890005, 1234567890005000000, false},
+		{"1234567890.5", 1234567890, 1234567890500, 1234567890500000000, false},
+		// errors
+		{"2006-13", 0, 0, 0, true},
+		{testing.T{}, 0, 0, 0, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := ToUnixE(test.input)
+		ms, errMilli := ToUnixMilliE(test.input)
+		ns, errNano := ToUnixNanoE(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			assert.Error(t, errMilli, errmsg)
+			assert.Error(t, errNano, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.NoError(t, errMilli, errmsg)
+		assert.NoError(t, errNano, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+		assert.Equal(t, test.expectMilli, ms, errmsg)
+		assert.Equal(t, test.expectNano, ns, errmsg)
+
+		// Non-E test
+		assert.Equal(t, test.expect, ToUnix(test.input), errmsg)
+		assert.Equal(t, test.expectMilli, ToUnixMilli(test.input), errmsg)
+		assert.Equal(t, test.expectNano, ToUnixNano(test.input), errmsg)
+	}
+}
+
+func TestToTimeStringE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		layout string
+		expect string
+		iserr  bool
+	}{
+		{int64(1234567890), time.RFC3339, "2009-02-13T23:31:30Z", false},
+		{uint32(1234567890), time.RFC3339, "2009-02-13T23:31:30Z", false},
+		{"2009-02-13 23:31:30", time.RFC3339, "2009-02-13T23:31:30Z", false},
+		{"2009-02-13T23:31:30+01:00", time.RFC3339, "2009-02-13T23:31:30+01:00", false},
+		{"13 Feb 2009", "2006-01-02", "2009-02-13", false},
+		{time.Date(2009, 2, 13, 23, 31, 30, 0, time.UTC), time.Kitchen, "11:31PM", false},
+		{"1234567890", time.RFC3339, "2009-02-13T23:31:30Z", false},
+		{[]byte("2009-02-13T23:31:30+01:00"), time.RFC3339, "2009-02-13T23:31:30+01:00", false},
+		{json.RawMessage(`"2009-02-13T23:31:30+01:00"`), time.RFC3339, "2009-02-13T23:31:30+01:00", false},
+		{level("2009-02-13T23:31:30+01:00"), time.RFC3339, "2009-02-13T23:31:30+01:00", false},
+		{[]byte("1234567890"), time.RFC3339, "2009-02-13T23:31:30Z", false},
+		{port(1234567890), time.RFC3339, "2009-02-13T23:31:30Z", false},
+		// errors
+		{"2006-13", time.RFC3339, "", true},
+		{testing.T{}, time.RFC3339, "", true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := ToTimeStringE(test.input, test.layout)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+
+		// Non-E test
+		v = ToTimeString(test.input, test.layout)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+
+	irn, err := time.LoadLocation("Iran")
+	require.NoError(t, err)
+
+	v, err := ToTimeStringInDefaultLocationE(int64(1234567890), time.RFC3339, irn)
+	require.NoError(t, err)
+	assert.Equal(t, "2009-02-14T03:01:30+03:30", v)
+
+	v, err = ToTimeStringInDefaultLocationE("2009-02-14 03:01:30", time.RFC3339, irn)
+	require.NoError(t, err)
+	assert.Equal(t, "2009-02-14T03:01:30+03:30", v)
+}
+
+func TestToDateE(t *testing.T) {
+	var jn json.Number
+	_ = json.Unmarshal([]byte("1234567890"), &jn)
+	irn, err := time.LoadLocation("Iran")
+	require.NoError(t, err)
+
+	tests := []struct {
+		input  interface{}
+		expect Date
+		iserr  bool
+	}{
+		{"2006-01-02", Date{2006, time.January, 2}, false},
+		{"02 Jan 2006", Date{2006, time.January, 2}, false},
+		{"2006-01-02T23:30:00-05:00", Date{2006, time.January, 2}, false},
+		{"2006-01-02 00:30:00 +09:00", Date{2006, time.January, 2}, false},
+		{"Mon, 02 Jan 2006 23:30:00 PST", Date{2006, time.January, 2}, false},
+		{time.Date(2006, 1, 2, 0, 30, 0, 0, irn), Date{2006, time.January, 2}, false},
+		{Date{2006, time.January, 2}, Date{2006, time.January, 2}, false},
+		{int64(1234567890), Date{2009, time.February, 13}, false},
+		{"1234567890", Date{2009, time.February, 13}, false},
+		{jn, Date{2009, time.February, 13}, false},
+		// errors
+		{"11:00PM", Date{}, true},
+		{"Nov 10 23:00:00", Date{}, true},
+		{"2006-13-01", Date{}, true},
+		{"20060102", Date{}, true},
+		{nil, Date{}, true},
+		{testing.T{}, Date{}, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := ToDateE(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+
+		// Non-E test
+		v = ToDate(test.input)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+
+	d := Date{2006, time.January, 2}
+	assert.Equal(t, "2006-01-02", ToString(d))
+	assert.Equal(t, d, ToDate(ToString(d)))
+	assert.Equal(t, time.Date(2006, 1, 2, 0, 0, 0, 0, irn), d.In(irn))
+
+	var decoded struct{ Birthday Date }
+	require.NoError(t, json.Unmarshal([]byte(`{"Birthday":"2006-01-02"}`), &decoded))
+	assert.Equal(t, d, decoded.Birthday)
+	b, err := json.Marshal(decoded)
+	require.NoError(t, err)
+	assert.Equal(t, `{"Birthday":"2006-01-02"}`, string(b))
+}
+
+func TestToLocationE(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	var jn json.Number
+	_ = json.Unmarshal([]byte("-3600"), &jn)
+
+	tests := []struct {
+		input  interface{}
+		expect *time.Location
+		iserr  bool
+	}{
+		{"Europe/Paris", paris, false},
+		{" Europe/Paris ", paris, false},
+		{"UTC", time.UTC, false},
+		{"utc", time.UTC, false},
+		{"", time.UTC, false},
+		{"Local", time.Local, false},
+		{"UTC+2", time.FixedZone("UTC+02:00", 2*60*60), false},
+		{"GMT-3", time.FixedZone("UTC-03:00", -3*60*60), false},
+		{"+05:30", time.FixedZone("UTC+05:30", 5*60*60+30*60), false},
+		{"-0800", time.FixedZone("UTC-08:00", -8*60*60), false},
+		{"UTC+00:00", time.UTC, false},
+		{paris, paris, false},
+		{(*time.Location)(nil), time.UTC, false},
+		{time.Date(2016, 1, 1, 0, 0, 0, 0, paris), paris, false},
+		{7200, time.FixedZone("UTC+02:00", 2*60*60), false},
+		{int32(19800), time.FixedZone("UTC+05:30", 5*60*60+30*60), false},
+		{float64(-28800), time.FixedZone("UTC-08:00", -8*60*60), false},
+		{jn, time.FixedZone("UTC-01:00", -60*60), false},
+		{90 * time.Minute, time.FixedZone("UTC+01:30", 90*60), false},
+		// errors
+		{"Nowhere/Special", nil, true},
+		{"UTC+", nil, true},
+		{"+5:3", nil, true},
+		{"+05:60", nil, true},
+		{"+123", nil, true},
+		{24 * 60 * 60, nil, true},
+		{float64(3600.5), nil, true},
+		{nil, nil, true},
+		{testing.T{}, nil, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := ToLocationE(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.True(t, locationEqual(test.expect, v), errmsg)
+
+		// Non-E test
+		v = ToLocation(test.input)
+		assert.True(t, locationEqual(test.expect, v), errmsg)
+	}
+
+	// Loaded locations are cached and safe for concurrent reuse.
+	done := make(chan *time.Location)
+	for i := 0; i < 4; i++ {
+		go func() {
+			done <- ToLocation("America/Los_Angeles")
+		}()
+	}
+	first := <-done
+	for i := 1; i < 4; i++ {
+		assert.True(t, first == <-done)
+	}
+	assert.Equal(t, "UTC+02:00", ToLocation("+02:00").String())
+	assert.True(t, ToLocation(7200) == ToLocation("UTC+2"))
+}
+
 func TestToDurationE(t *testing.T) {
 	var td time.Duration = 5
 	var jn json.Number
@@ -1540,6 +2726,73 @@ func TestToTimeWithTimezones(t *testing.T) {
 	}
 }
 
+func TestToTimeWithTimezoneAbbreviations(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	irn, err := time.LoadLocation("Iran")
+	require.NoError(t, err)
+
+	require.NoError(t, SetTimezoneAbbreviations(DefaultTimezoneAbbreviations))
+	defer SetTimezoneAbbreviations(nil)
+
+	tests := []struct {
+		input  string
+		expect time.Time
+	}{
+		{"Mon, 02 Jan 2006 15:04:05 PST", time.Date(2006, 1, 2, 15, 4, 5, 0, la)},       // RFC1123
+		{"Sun, 02 Jul 2006 15:04:05 PDT", time.Date(2006, 7, 2, 15, 4, 5, 0, la)},       // RFC1123
+		{"02 Jan 06 15:04 CET", time.Date(2006, 1, 2, 15, 4, 0, 0, berlin)},             // RFC822
+		{"Monday, 02-Jan-06 15:04:05 CET", time.Date(2006, 1, 2, 15, 4, 5, 0, berlin)},  // RFC850
+		{"Mon Jan  2 15:04:05 PST 2006", time.Date(2006, 1, 2, 15, 4, 5, 0, la)},        // UnixDate
+		{"Mon, 02 Jan 2006 15:04:05 GMT", time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)}, // RFC1123
+		{"Mon, 02 Jan 2006 15:04:05 XYZ", time.Date(2006, 1, 2, 15, 4, 5, 0, irn)},      // unknown abbreviation
+		{"2006-01-02 15:04:05 -0800 PST", time.Date(2006, 1, 2, 15, 4, 5, 0, la)},       // Time.String()
+		{"2006-01-02T15:04:05", time.Date(2006, 1, 2, 15, 4, 5, 0, irn)},                // no timezone
+		{"Mon, 02 Jan 2006 15:04:05 -0800", time.Date(2006, 1, 2, 15, 4, 5, 0, la)},     // RFC1123Z
+		// DST boundaries
+		{"Sun, 05 Nov 2023 01:30:00 PDT", time.Date(2023, 11, 5, 8, 30, 0, 0, time.UTC)},
+		{"Sun, 05 Nov 2023 01:30:00 PST", time.Date(2023, 11, 5, 9, 30, 0, 0, time.UTC)},
+		{"Sun, 12 Mar 2023 01:59:59 PST", time.Date(2023, 3, 12, 9, 59, 59, 0, time.UTC)},
+		{"Sun, 12 Mar 2023 03:00:00 PDT", time.Date(2023, 3, 12, 10, 0, 0, 0, time.UTC)},
+		{"Sun, 26 Mar 2023 01:30:00 CET", time.Date(2023, 3, 26, 0, 30, 0, 0, time.UTC)},
+		{"Sun, 29 Oct 2023 02:30:00 CEST", time.Date(2023, 10, 29, 0, 30, 0, 0, time.UTC)},
+		{"Sun, 29 Oct 2023 02:30:00 CET", time.Date(2023, 10, 29, 1, 30, 0, 0, time.UTC)},
+		// abbreviations used in the wrong season
+		{"Mon, 02 Jan 2006 15:04:05 PDT", time.Date(2006, 1, 2, 22, 4, 5, 0, time.UTC)},
+		{"Sun, 02 Jul 2006 15:04:05 EST", time.Date(2006, 7, 2, 20, 4, 5, 0, time.UTC)},
+		{"Sun, 02 Jul 2006 15:04:05 CET", time.Date(2006, 7, 2, 14, 4, 5, 0, time.UTC)},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := ToTimeInDefaultLocationE(test.input, irn)
+		assert.NoError(t, err, errmsg)
+		assert.True(t, test.expect.Equal(v), errmsg)
+	}
+
+	// The time is in the mapped location if it uses the abbreviation then,
+	// and keeps the stated offset otherwise.
+	v, err := ToTimeE("Sun, 05 Nov 2023 01:30:00 PST")
+	require.NoError(t, err)
+	assertLocationEqual(t, la, v.Location())
+	v, err = ToTimeE("Mon, 02 Jan 2006 15:04:05 PDT")
+	require.NoError(t, err)
+	name, offset := v.Zone()
+	assert.Equal(t, "PDT", name)
+	assert.Equal(t, -7*60*60, offset)
+
+	assert.Error(t, SetTimezoneAbbreviations(map[string]string{"XYZ": "Nowhere/Special"}))
+
+	// Disabling the mapping restores the default location behaviour.
+	require.NoError(t, SetTimezoneAbbreviations(nil))
+	v, err = ToTimeInDefaultLocationE("Mon, 02 Jan 2006 15:04:05 PST", irn)
+	require.NoError(t, err)
+	assertTimeEqual(t, time.Date(2006, 1, 2, 15, 4, 5, 0, irn), v)
+}
+
 func assertTimeEqual(t *testing.T, expected, actual time.Time) {
 	t.Helper()
 	// Compare the dates using a numeric zone as there are cases where
@@ -1,70 +1,371 @@
 package castlearn
 
 import (
+	"database/sql/driver"
+	"encoding"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"html/template"
+	"io"
+	"math"
+	"math/big"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
-var errNegativeNotAllowed = errors.New("unable to cast negative value")
+var (
+	// ErrNegativeNotAllowed is wrapped by the errors of the unsigned casts
+	// of negative values.
+	ErrNegativeNotAllowed = errors.New("negative value not allowed")
+	// ErrValueOutOfRange is wrapped by the errors of casts of values that
+	// do not fit in the target type.
+	ErrValueOutOfRange = errors.New("value out of range")
+	// ErrNotFinite is wrapped by the errors of casts of NaN and ±Inf to
+	// integers, and to floats if the FloatPolicy rejects them.
+	ErrNotFinite = errors.New("value is not finite")
+	// ErrNonZeroImaginary is wrapped by the errors of the real-valued casts
+	// of complex numbers with a non-zero imaginary part.
+	ErrNonZeroImaginary = errors.New("imaginary part is not zero")
+	// ErrCycle is wrapped by the errors of ToStringMapDeepE for values that
+	// contain themselves.
+	ErrCycle = errors.New("value contains a cycle")
+)
 
-// ToTimeE casts an interface to a time.Time type.
+// ToTimeE calls [Caster.ToTimeE] with the package options.
 func ToTimeE(i interface{}) (time.Time, error) {
-	return ToTimeInDefaultLocationE(i, time.UTC)
+	return defaultCaster().ToTimeE(i)
+}
+
+// ToTimeE casts an interface to a time.Time type.
+func (c Caster) ToTimeE(i interface{}) (time.Time, error) {
+	return c.ToTimeInDefaultLocationE(i, time.UTC)
+}
+
+// ToTimeInDefaultLocationE calls [Caster.ToTimeInDefaultLocationE]
+// with the package options.
+func ToTimeInDefaultLocationE(i interface{}, location *time.Location) (tim time.Time, err error) {
+	return defaultCaster().ToTimeInDefaultLocationE(i, location)
 }
 
 // ToTimeInDefaultLocationE casts an empty interface to time.Time,
 // interpreting inputs without a timezone to be in the given location,
-// or the local timezone if nil.
-func ToTimeInDefaultLocationE(i interface{}, location *time.Location) (tim time.Time, err error) {
+// or the local timezone if nil. Numbers are Unix times in seconds, and so
+// are numeric strings that are not dates, unless they are eight digits or
+// fewer like the year "2006".
+func (c Caster) ToTimeInDefaultLocationE(i interface{}, location *time.Location) (tim time.Time, err error) {
 	i = indirect(i)
 
 	switch v := i.(type) {
 	case time.Time:
 		return v, nil
+	case []byte, json.RawMessage:
+		text := byteString(i)
+		return c.ToTimeInDefaultLocationE(text, location)
 	case string:
-		return StringToDateInDefaultLocation(v, location)
+		if t, ok := stringUnixTime(v); ok {
+			return t, nil
+		}
+		return c.StringToDateInDefaultLocation(v, location)
 	case json.Number:
-		s, err1 := v.Int64()
-		if err1 != nil {
-			return time.Time{}, fmt.Errorf("unable to cast %#v of type %T to Time", i, i)
+		if t, ok := unixStringToTime(v.String()); ok {
+			return t, nil
 		}
-		return time.Unix(s, 0), nil
+		return time.Time{}, fmt.Errorf("unable to cast %#v of type %T to Time", i, i)
 	case int:
 		return time.Unix(int64(v), 0), nil
 	case int64:
 		return time.Unix(v, 0), nil
 	case int32:
 		return time.Unix(int64(v), 0), nil
+	case int16:
+		return time.Unix(int64(v), 0), nil
+	case int8:
+		return time.Unix(int64(v), 0), nil
 	case uint:
 		return time.Unix(int64(v), 0), nil
 	case uint64:
 		return time.Unix(int64(v), 0), nil
 	case uint32:
 		return time.Unix(int64(v), 0), nil
+	case uint16:
+		return time.Unix(int64(v), 0), nil
+	case uint8:
+		return time.Unix(int64(v), 0), nil
+	case float64:
+		if t, ok := unixFloatToTime(v); ok {
+			return t, nil
+		}
+		return time.Time{}, fmt.Errorf("unable to cast %#v of type %T to Time", i, i)
+	case float32:
+		if t, ok := unixFloatToTime(float64(v)); ok {
+			return t, nil
+		}
+		return time.Time{}, fmt.Errorf("unable to cast %#v of type %T to Time", i, i)
+	case time.Duration:
+		return time.Unix(0, int64(v)), nil
 	default:
+		if v, ok := underlyingValue(i); ok {
+			return c.ToTimeInDefaultLocationE(v, location)
+		}
 		return time.Time{}, fmt.Errorf("unable to cast %#v of type %T to Time", i, i)
 	}
 }
 
-// ToDurationE casts an interface to a time.Duration type.
+// ToUnixE calls [Caster.ToUnixE] with the package options.
+func ToUnixE(i interface{}) (int64, error) {
+	return defaultCaster().ToUnixE(i)
+}
+
+// ToUnixE casts an interface to a Unix time in seconds.
+func (c Caster) ToUnixE(i interface{}) (int64, error) {
+	t, err := c.ToTimeE(i)
+	if err != nil {
+		return 0, err
+	}
+	return t.Unix(), nil
+}
+
+// ToUnixMilliE calls [Caster.ToUnixMilliE] with the package options.
+func ToUnixMilliE(i interface{}) (int64, error) {
+	return defaultCaster().ToUnixMilliE(i)
+}
+
+// ToUnixMilliE casts an interface to a Unix time in milliseconds.
+func (c Caster) ToUnixMilliE(i interface{}) (int64, error) {
+	t, err := c.ToTimeE(i)
+	if err != nil {
+		return 0, err
+	}
+	return t.UnixMilli(), nil
+}
+
+// ToUnixNanoE calls [Caster.ToUnixNanoE] with the package options.
+func ToUnixNanoE(i interface{}) (int64, error) {
+	return defaultCaster().ToUnixNanoE(i)
+}
+
+// ToUnixNanoE casts an interface to a Unix time in nanoseconds.
+func (c Caster) ToUnixNanoE(i interface{}) (int64, error) {
+	t, err := c.ToTimeE(i)
+	if err != nil {
+		return 0, err
+	}
+	return t.UnixNano(), nil
+}
+
+// ToTimeStringE calls [Caster.ToTimeStringE] with the package options.
+func ToTimeStringE(i interface{}, layout string) (string, error) {
+	return defaultCaster().ToTimeStringE(i, layout)
+}
+
+// ToTimeStringE casts an interface to a time.Time and formats it with the
+// given layout, e.g. time.RFC3339.
+func (c Caster) ToTimeStringE(i interface{}, layout string) (string, error) {
+	return c.ToTimeStringInDefaultLocationE(i, layout, time.UTC)
+}
+
+// ToTimeStringInDefaultLocationE calls [Caster.ToTimeStringInDefaultLocationE]
+// with the package options.
+func ToTimeStringInDefaultLocationE(i interface{}, layout string, location *time.Location) (string, error) {
+	return defaultCaster().ToTimeStringInDefaultLocationE(i, layout, location)
+}
+
+// ToTimeStringInDefaultLocationE casts an interface to a time.Time and
+// formats it with the given layout. Inputs without a timezone, including
+// Unix times, are interpreted and formatted in the given location, or the
+// local timezone if nil.
+func (c Caster) ToTimeStringInDefaultLocationE(i interface{}, layout string, location *time.Location) (string, error) {
+	t, err := c.ToTimeInDefaultLocationE(i, location)
+	if err != nil {
+		return "", err
+	}
+	if isUnixTime(i) {
+		// Unix times carry no zone of their own, so present them in the
+		// default location instead of the local timezone of the machine.
+		if location == nil {
+			location = time.Local
+		}
+		t = t.In(location)
+	}
+	return t.Format(layout), nil
+}
+
+// isUnixTime reports whether i is cast to a time.Time as a Unix time rather
+// than parsed as a date.
+func isUnixTime(i interface{}) bool {
+	i = indirect(i)
+	switch v := i.(type) {
+	case time.Time:
+		return false
+	case []byte, json.RawMessage:
+		return isUnixTime(byteString(i))
+	case string:
+		_, ok := stringUnixTime(v)
+		return ok
+	}
+	if v, ok := underlyingValue(i); ok {
+		return isUnixTime(v)
+	}
+	return true
+}
+
+// unixStringToTime interprets a numeric string as a Unix time in seconds,
+// with an optional fractional part.
+func unixStringToTime(s string) (time.Time, bool) {
+	if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(v, 0), true
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return unixFloatToTime(v)
+}
+
+// stringUnixTime interprets a string as a Unix time like unixStringToTime
+// if it is not a date. The date layouts are tried first, and strings of up
+// to eight digits are not Unix times either: "2006" or "20060102" is more
+// likely a year or a compact date than a time in 1970, at the cost of not
+// casting such strings to times before March 1973.
+func stringUnixTime(s string) (time.Time, bool) {
+	t, ok := unixStringToTime(s)
+	if !ok || len(s) <= 8 && strings.Trim(s, "0123456789") == "" {
+		return time.Time{}, false
+	}
+	for _, format := range timeFormats {
+		if _, err := time.Parse(format.format, s); err == nil {
+			return time.Time{}, false
+		}
+	}
+	return t, true
+}
+
+// unixFloatToTime converts fractional Unix seconds to a time.Time. The
+// fraction is rounded to microseconds, about the finest resolution a
+// float64 holds for present-day timestamps. The seconds must fit in an
+// int64.
+func unixFloatToTime(f float64) (time.Time, bool) {
+	if math.IsNaN(f) || f < -(1<<63) || f >= 1<<63 {
+		return time.Time{}, false
+	}
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(math.Round(frac*1e6))*int64(time.Microsecond)), true
+}
+
+// ToDateE calls [Caster.ToDateE] with the package options.
+func ToDateE(i interface{}) (Date, error) {
+	return defaultCaster().ToDateE(i)
+}
+
+// ToDateE casts an interface to a Date. Dates parsed from strings are kept
+// as written, whatever timezone the input has; Unix times are taken in UTC.
+func (c Caster) ToDateE(i interface{}) (Date, error) {
+	i = indirect(i)
+
+	switch v := i.(type) {
+	case Date:
+		return v, nil
+	case time.Time:
+		return DateOf(v), nil
+	case []byte, json.RawMessage:
+		text := byteString(i)
+		return c.ToDateE(text)
+	case string:
+		if t, ok := stringUnixTime(v); ok {
+			return DateOf(t.UTC()), nil
+		}
+		t, err := c.parseDateWith(v, time.UTC, dateFormats)
+		if err != nil {
+			return Date{}, fmt.Errorf("unable to cast %#v of type %T to Date", i, i)
+		}
+		return DateOf(t), nil
+	}
+
+	t, err := c.ToTimeE(i)
+	if err != nil {
+		return Date{}, fmt.Errorf("unable to cast %#v of type %T to Date", i, i)
+	}
+	return DateOf(t.UTC()), nil
+}
+
+// ToLocationE calls [Caster.ToLocationE] with the package options.
+func ToLocationE(i interface{}) (*time.Location, error) {
+	return defaultCaster().ToLocationE(i)
+}
+
+// ToLocationE casts an interface to a *time.Location. Strings may be IANA
+// names such as "Europe/Paris" or fixed offsets such as "+05:30" or
+// "UTC+2"; numbers are taken as offsets east of UTC in seconds.
+func (c Caster) ToLocationE(i interface{}) (*time.Location, error) {
+	if loc, ok := i.(*time.Location); ok {
+		if loc == nil {
+			return time.UTC, nil
+		}
+		return loc, nil
+	}
+
+	i = indirect(i)
+
+	switch v := i.(type) {
+	case time.Time:
+		return v.Location(), nil
+	case []byte, json.RawMessage:
+		text := byteString(i)
+		return c.ToLocationE(text)
+	case string:
+		loc, err := stringToLocation(v)
+		if err != nil {
+			return nil, fmt.Errorf("unable to cast %#v of type %T to *time.Location: %s", i, i, err)
+		}
+		return loc, nil
+	case time.Duration:
+		return offsetToLocation(int64(v / time.Second))
+	case int, int64, int32, int16, int8, uint, uint64, uint32, uint16, uint8, json.Number:
+		offset, err := c.ToInt64E(v)
+		if err != nil {
+			return nil, fmt.Errorf("unable to cast %#v of type %T to *time.Location", i, i)
+		}
+		return offsetToLocation(offset)
+	case float64, float32:
+		f := c.ToFloat64(v)
+		if f != math.Trunc(f) {
+			return nil, fmt.Errorf("unable to cast %#v of type %T to *time.Location", i, i)
+		}
+		return offsetToLocation(int64(f))
+	default:
+		if v, ok := underlyingValue(i); ok {
+			return c.ToLocationE(v)
+		}
+		return nil, fmt.Errorf("unable to cast %#v of type %T to *time.Location", i, i)
+	}
+}
+
+// ToDurationE calls [Caster.ToDurationE] with the package options.
 func ToDurationE(i interface{}) (time.Duration, error) {
+	return defaultCaster().ToDurationE(i)
+}
+
+// ToDurationE casts an interface to a time.Duration type.
+func (c Caster) ToDurationE(i interface{}) (time.Duration, error) {
 	i = indirect(i)
 
 	switch s := i.(type) {
 	case time.Duration:
 		return s, nil
 	case int, int64, int32, int16, int8, uint, uint64, uint32, uint16, uint8:
-		d := time.Duration(ToInt64(i))
+		d := time.Duration(c.ToInt64(i))
 		return d, nil
 	case float32, float64:
-		d := time.Duration(ToFloat64(i))
+		d := time.Duration(c.ToFloat64(i))
 		return d, nil
+	case []byte, json.RawMessage:
+		text := byteString(i)
+		return c.ToDurationE(text)
 	case string:
 		if strings.ContainsAny(s, "nsuµmh") {
 			return time.ParseDuration(s)
@@ -76,12 +377,20 @@ func ToDurationE(i interface{}) (time.Duration, error) {
 		d := time.Duration(v)
 		return d, err
 	default:
+		if v, ok := underlyingValue(i); ok {
+			return c.ToDurationE(v)
+		}
 		return time.Duration(0), fmt.Errorf("unable to cast %#v of type %T to Duration", i, i)
 	}
 }
 
-// ToBoolE casts an interface to a bool type.
+// ToBoolE calls [Caster.ToBoolE] with the package options.
 func ToBoolE(i interface{}) (bool, error) {
+	return defaultCaster().ToBoolE(i)
+}
+
+// ToBoolE casts an interface to a bool type.
+func (c Caster) ToBoolE(i interface{}) (bool, error) {
 	i = indirect(i)
 
 	switch b := i.(type) {
@@ -115,6 +424,9 @@ func ToBoolE(i interface{}) (bool, error) {
 		return b != 0, nil
 	case time.Duration:
 		return b != 0, nil
+	case []byte, json.RawMessage:
+		text := byteString(i)
+		return c.ToBoolE(text)
 	case string:
 		return strconv.ParseBool(i.(string))
 	case json.Number:
@@ -124,12 +436,29 @@ func ToBoolE(i interface{}) (bool, error) {
 		}
 		return false, fmt.Errorf("unable to cast %#v of type %T to bool", i, i)
 	default:
+		if v, ok := underlyingValue(i); ok {
+			return c.ToBoolE(v)
+		}
 		return false, fmt.Errorf("unable to Cast %#v of type %T to bool\n", i, i)
 	}
 }
 
-// ToFloat64E casts an interface to a float64 type.
+// ToFloat64E calls [Caster.ToFloat64E] with the package options.
 func ToFloat64E(i interface{}) (float64, error) {
+	return defaultCaster().ToFloat64E(i)
+}
+
+// ToFloat64E casts an interface to a float64 type. NaN and ±Inf fail if the
+// FloatPolicy rejects non-finite values.
+func (c Caster) ToFloat64E(i interface{}) (float64, error) {
+	v, err := c.toFloat64E(i)
+	if err != nil {
+		return 0, err
+	}
+	return c.applyFloatPolicy(i, v, "float64")
+}
+
+func (c Caster) toFloat64E(i interface{}) (float64, error) {
 	i = indirect(i)
 
 	switch s := i.(type) {
@@ -157,8 +486,11 @@ func ToFloat64E(i interface{}) (float64, error) {
 		return float64(s), nil
 	case uint8:
 		return float64(s), nil
+	case []byte, json.RawMessage:
+		text := byteString(i)
+		return c.toFloat64E(text)
 	case string:
-		v, err := strconv.ParseFloat(s, 64)
+		v, err := c.parseFloat(s, 64)
 		if err == nil {
 			return v, nil
 		}
@@ -169,18 +501,41 @@ func ToFloat64E(i interface{}) (float64, error) {
 			return v, nil
 		}
 		return 0, fmt.Errorf("unable to cast %#v of type %T to float64", i, i)
+	case complex128, complex64:
+		return complexToReal(s, "float64")
+	case *big.Int, *big.Float, *big.Rat:
+		v, err := bigToFloat(s, 64)
+		return float64(v), err
 	case bool:
 		if s {
 			return 1, nil
 		}
 		return 0, nil
 	default:
+		if v, ok := underlyingValue(i); ok {
+			return c.toFloat64E(v)
+		}
 		return 0, fmt.Errorf("unable to cast %#v of type %T to float64", i, i)
 	}
 }
 
-// ToFloat32E casts an interface to a float32 type.
+// ToFloat32E calls [Caster.ToFloat32E] with the package options.
 func ToFloat32E(i interface{}) (float32, error) {
+	return defaultCaster().ToFloat32E(i)
+}
+
+// ToFloat32E casts an interface to a float32 type. NaN and ±Inf fail if the
+// FloatPolicy rejects non-finite values.
+func (c Caster) ToFloat32E(i interface{}) (float32, error) {
+	v, err := c.toFloat32E(i)
+	if err != nil {
+		return 0, err
+	}
+	f, err := c.applyFloatPolicy(i, float64(v), "float32")
+	return float32(f), err
+}
+
+func (c Caster) toFloat32E(i interface{}) (float32, error) {
 	i = indirect(i)
 
 	switch s := i.(type) {
@@ -208,8 +563,11 @@ func ToFloat32E(i interface{}) (float32, error) {
 		return float32(s), nil
 	case uint8:
 		return float32(s), nil
+	case []byte, json.RawMessage:
+		text := byteString(i)
+		return c.toFloat32E(text)
 	case string:
-		v, err := strconv.ParseFloat(s, 32)
+		v, err := c.parseFloat(s, 32)
 		if err == nil {
 			return float32(v), nil
 		}
@@ -220,18 +578,208 @@ func ToFloat32E(i interface{}) (float32, error) {
 			return float32(v), nil
 		}
 		return 0, fmt.Errorf("unable to cast %#v of type %T to float32", i, i)
+	case complex128, complex64:
+		v, err := complexToReal(s, "float32")
+		return float32(v), err
+	case *big.Int, *big.Float, *big.Rat:
+		v, err := bigToFloat(s, 32)
+		return float32(v), err
 	case bool:
 		if s {
 			return 1, nil
 		}
 		return 0, nil
 	default:
+		if v, ok := underlyingValue(i); ok {
+			return c.toFloat32E(v)
+		}
 		return 0, fmt.Errorf("unable to cast %#v of type %T to float32", i, i)
 	}
 }
 
-// ToInt64E casts an interface to an int64 type.
+// ToComplex128E calls [Caster.ToComplex128E] with the package options.
+func ToComplex128E(i interface{}) (complex128, error) {
+	return defaultCaster().ToComplex128E(i)
+}
+
+// ToComplex128E casts an interface to a complex128 type. Strings are parsed
+// with strconv.ParseComplex, e.g. "1+2i", and real numbers have a zero
+// imaginary part.
+func (c Caster) ToComplex128E(i interface{}) (complex128, error) {
+	return c.toComplexE(i, 128, "complex128")
+}
+
+// ToComplex64E calls [Caster.ToComplex64E] with the package options.
+func ToComplex64E(i interface{}) (complex64, error) {
+	return defaultCaster().ToComplex64E(i)
+}
+
+// ToComplex64E casts an interface to a complex64 type.
+func (c Caster) ToComplex64E(i interface{}) (complex64, error) {
+	v, err := c.toComplexE(i, 64, "complex64")
+	return complex64(v), err
+}
+
+// toComplexE casts an interface to a complex128 that fits in a complex
+// number of the given bit size, named typ in errors.
+func (c Caster) toComplexE(i interface{}, bitSize int, typ string) (complex128, error) {
+	i = indirect(i)
+
+	switch s := i.(type) {
+	case complex128:
+		return s, nil
+	case complex64:
+		return complex128(s), nil
+	case []byte, json.RawMessage:
+		text := byteString(i)
+		return c.toComplexE(text, bitSize, typ)
+	case string:
+		v, err := strconv.ParseComplex(strings.TrimSpace(s), bitSize)
+		if err == nil {
+			return v, nil
+		}
+		return 0, fmt.Errorf("unable to cast %#v of type %T to %s", i, i, typ)
+	case json.Number:
+		v, err := strconv.ParseComplex(s.String(), bitSize)
+		if err == nil {
+			return v, nil
+		}
+		return 0, fmt.Errorf("unable to cast %#v of type %T to %s", i, i, typ)
+	case nil:
+		return 0, nil
+	default:
+		if v, ok := underlyingValue(i); ok {
+			return c.toComplexE(v, bitSize, typ)
+		}
+		v, err := c.toFloat64E(i)
+		if err != nil {
+			return 0, fmt.Errorf("unable to cast %#v of type %T to %s", i, i, typ)
+		}
+		return complex(v, 0), nil
+	}
+}
+
+// complexToReal returns the real part of the complex number i, which must
+// have a zero imaginary part to be cast to the real type typ.
+func complexToReal(i interface{}, typ string) (float64, error) {
+	var c complex128
+	switch s := i.(type) {
+	case complex128:
+		c = s
+	case complex64:
+		c = complex128(s)
+	}
+	if imag(c) != 0 {
+		return 0, fmt.Errorf("unable to cast %#v of type %T to %s: %w", i, i, typ, ErrNonZeroImaginary)
+	}
+	return real(c), nil
+}
+
+// ToRatioE calls [Caster.ToRatioE] with the package options.
+func ToRatioE(i interface{}) (float64, error) {
+	return defaultCaster().ToRatioE(i)
+}
+
+// ToRatioE casts an interface to a fraction such as 0.75. It accepts the
+// inputs of ToFloat64E, percent strings such as "75%" and fraction strings
+// such as "3/4".
+func (c Caster) ToRatioE(i interface{}) (float64, error) {
+	return c.toRatioE(i, 1)
+}
+
+// ToPercentE calls [Caster.ToPercentE] with the package options.
+func ToPercentE(i interface{}) (float64, error) {
+	return defaultCaster().ToPercentE(i)
+}
+
+// ToPercentE is like ToRatioE, except that plain numbers are percentages,
+// so both 75 and "75%" give 0.75.
+func (c Caster) ToPercentE(i interface{}) (float64, error) {
+	return c.toRatioE(i, 100)
+}
+
+// ToUnitRatioE calls [Caster.ToUnitRatioE] with the package options.
+func ToUnitRatioE(i interface{}) (float64, error) {
+	return defaultCaster().ToUnitRatioE(i)
+}
+
+// ToUnitRatioE is like ToRatioE, but fails for fractions outside [0, 1].
+func (c Caster) ToUnitRatioE(i interface{}) (float64, error) {
+	v, err := c.ToRatioE(i)
+	if err != nil {
+		return 0, err
+	}
+	if v < 0 || v > 1 {
+		return 0, fmt.Errorf("unable to cast %#v of type %T to a ratio in [0, 1]: %w", i, i, ErrValueOutOfRange)
+	}
+	return v, nil
+}
+
+// toRatioE casts an interface to a fraction, dividing plain numbers by the
+// given scale.
+func (c Caster) toRatioE(i interface{}, scale float64) (float64, error) {
+	i = indirect(i)
+
+	var v float64
+	switch s := i.(type) {
+	case []byte, json.RawMessage:
+		text := byteString(i)
+		return c.toRatioE(text, scale)
+	case string:
+		var err error
+		if v, err = c.parseRatio(s, scale); err != nil {
+			return 0, fmt.Errorf("unable to cast %#v of type %T to ratio", i, i)
+		}
+	default:
+		if v, ok := underlyingValue(i); ok {
+			return c.toRatioE(v, scale)
+		}
+		f, err := c.ToFloat64E(i)
+		if err != nil {
+			return 0, fmt.Errorf("unable to cast %#v of type %T to ratio", i, i)
+		}
+		v = f / scale
+	}
+
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("unable to cast %#v of type %T to ratio", i, i)
+	}
+	return v, nil
+}
+
+// parseRatio parses a percent string such as "75%", a fraction string such
+// as "3/4", or a plain number, which is divided by the given scale.
+func (c Caster) parseRatio(s string, scale float64) (float64, error) {
+	s = strings.TrimSpace(s)
+	if p := strings.TrimSuffix(s, "%"); p != s {
+		v, err := c.parseFloat(strings.TrimSpace(p), 64)
+		return v / 100, err
+	}
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		num, err := c.parseFloat(strings.TrimSpace(s[:i]), 64)
+		if err != nil {
+			return 0, err
+		}
+		den, err := c.parseFloat(strings.TrimSpace(s[i+1:]), 64)
+		if err != nil {
+			return 0, err
+		}
+		if den == 0 {
+			return 0, errors.New("division by zero")
+		}
+		return num / den, nil
+	}
+	v, err := c.parseFloat(s, 64)
+	return v / scale, err
+}
+
+// ToInt64E calls [Caster.ToInt64E] with the package options.
 func ToInt64E(i interface{}) (int64, error) {
+	return defaultCaster().ToInt64E(i)
+}
+
+// ToInt64E casts an interface to an int64 type.
+func (c Caster) ToInt64E(i interface{}) (int64, error) {
 	i = indirect(i)
 
 	switch s := i.(type) {
@@ -256,11 +804,20 @@ func ToInt64E(i interface{}) (int64, error) {
 	case uint8:
 		return int64(s), nil
 	case float64:
+		if !isFinite(s) {
+			return 0, fmt.Errorf("unable to cast %#v of type %T to int64: %w", i, i, ErrNotFinite)
+		}
 		return int64(s), nil
 	case float32:
+		if !isFinite(float64(s)) {
+			return 0, fmt.Errorf("unable to cast %#v of type %T to int64: %w", i, i, ErrNotFinite)
+		}
 		return int64(s), nil
+	case []byte, json.RawMessage:
+		text := byteString(i)
+		return c.ToInt64E(text)
 	case string:
-		v, err := strconv.ParseInt(s, 0, 0)
+		v, err := c.parseInt(s, 0)
 		if err == nil {
 			return v, nil
 		}
@@ -271,6 +828,14 @@ func ToInt64E(i interface{}) (int64, error) {
 			return v, nil
 		}
 		return 0, fmt.Errorf("unable to cast %#v of type %T to int64", i, i)
+	case complex128, complex64:
+		v, err := complexToReal(s, "int64")
+		if err != nil {
+			return 0, err
+		}
+		return c.ToInt64E(v)
+	case *big.Int, *big.Float, *big.Rat:
+		return bigToInt64(s, 64)
 	case bool:
 		if s {
 			return 1, nil
@@ -279,12 +844,20 @@ func ToInt64E(i interface{}) (int64, error) {
 	case nil:
 		return 0, nil
 	default:
+		if v, ok := underlyingValue(i); ok {
+			return c.ToInt64E(v)
+		}
 		return 0, fmt.Errorf("unable to Cast %#v of type %T to int64\n", i, i)
 	}
 }
 
-// ToInt32E casts an interface to an int32 type.
+// ToInt32E calls [Caster.ToInt32E] with the package options.
 func ToInt32E(i interface{}) (int32, error) {
+	return defaultCaster().ToInt32E(i)
+}
+
+// ToInt32E casts an interface to an int32 type.
+func (c Caster) ToInt32E(i interface{}) (int32, error) {
 	i = indirect(i)
 
 	switch s := i.(type) {
@@ -309,11 +882,20 @@ func ToInt32E(i interface{}) (int32, error) {
 	case uint8:
 		return int32(s), nil
 	case float64:
+		if !isFinite(s) {
+			return 0, fmt.Errorf("unable to cast %#v of type %T to int32: %w", i, i, ErrNotFinite)
+		}
 		return int32(s), nil
 	case float32:
+		if !isFinite(float64(s)) {
+			return 0, fmt.Errorf("unable to cast %#v of type %T to int32: %w", i, i, ErrNotFinite)
+		}
 		return int32(s), nil
+	case []byte, json.RawMessage:
+		text := byteString(i)
+		return c.ToInt32E(text)
 	case string:
-		v, err := strconv.ParseInt(s, 0, 0)
+		v, err := c.parseInt(s, 0)
 		if err == nil {
 			return int32(v), nil
 		}
@@ -324,6 +906,15 @@ func ToInt32E(i interface{}) (int32, error) {
 			return int32(v), nil
 		}
 		return 0, fmt.Errorf("unable to cast %#v of type %T to int32", i, i)
+	case complex128, complex64:
+		v, err := complexToReal(s, "int32")
+		if err != nil {
+			return 0, err
+		}
+		return c.ToInt32E(v)
+	case *big.Int, *big.Float, *big.Rat:
+		v, err := bigToInt64(s, 32)
+		return int32(v), err
 	case bool:
 		if s {
 			return 1, nil
@@ -332,12 +923,20 @@ func ToInt32E(i interface{}) (int32, error) {
 	case nil:
 		return 0, nil
 	default:
+		if v, ok := underlyingValue(i); ok {
+			return c.ToInt32E(v)
+		}
 		return 0, fmt.Errorf("unable to Cast %#v of type %T to int32\n", i, i)
 	}
 }
 
-// ToInt16E casts an interface to an int16 type.
+// ToInt16E calls [Caster.ToInt16E] with the package options.
 func ToInt16E(i interface{}) (int16, error) {
+	return defaultCaster().ToInt16E(i)
+}
+
+// ToInt16E casts an interface to an int16 type.
+func (c Caster) ToInt16E(i interface{}) (int16, error) {
 	i = indirect(i)
 
 	switch s := i.(type) {
@@ -362,11 +961,20 @@ func ToInt16E(i interface{}) (int16, error) {
 	case uint8:
 		return int16(s), nil
 	case float64:
+		if !isFinite(s) {
+			return 0, fmt.Errorf("unable to cast %#v of type %T to int16: %w", i, i, ErrNotFinite)
+		}
 		return int16(s), nil
 	case float32:
+		if !isFinite(float64(s)) {
+			return 0, fmt.Errorf("unable to cast %#v of type %T to int16: %w", i, i, ErrNotFinite)
+		}
 		return int16(s), nil
+	case []byte, json.RawMessage:
+		text := byteString(i)
+		return c.ToInt16E(text)
 	case string:
-		v, err := strconv.ParseInt(s, 0, 0)
+		v, err := c.parseInt(s, 0)
 		if err == nil {
 			return int16(v), nil
 		}
@@ -377,6 +985,15 @@ func ToInt16E(i interface{}) (int16, error) {
 			return int16(v), nil
 		}
 		return 0, fmt.Errorf("unable to cast %#v of type %T to int16", i, i)
+	case complex128, complex64:
+		v, err := complexToReal(s, "int16")
+		if err != nil {
+			return 0, err
+		}
+		return c.ToInt16E(v)
+	case *big.Int, *big.Float, *big.Rat:
+		v, err := bigToInt64(s, 16)
+		return int16(v), err
 	case bool:
 		if s {
 			return 1, nil
@@ -385,12 +1002,20 @@ func ToInt16E(i interface{}) (int16, error) {
 	case nil:
 		return 0, nil
 	default:
+		if v, ok := underlyingValue(i); ok {
+			return c.ToInt16E(v)
+		}
 		return 0, fmt.Errorf("unable to Cast %#v of type %T to int16\n", i, i)
 	}
 }
 
-// ToInt8E casts an interface to an int8 type.
+// ToInt8E calls [Caster.ToInt8E] with the package options.
 func ToInt8E(i interface{}) (int8, error) {
+	return defaultCaster().ToInt8E(i)
+}
+
+// ToInt8E casts an interface to an int8 type.
+func (c Caster) ToInt8E(i interface{}) (int8, error) {
 	i = indirect(i)
 
 	switch s := i.(type) {
@@ -415,11 +1040,20 @@ func ToInt8E(i interface{}) (int8, error) {
 	case uint8:
 		return int8(s), nil
 	case float64:
+		if !isFinite(s) {
+			return 0, fmt.Errorf("unable to cast %#v of type %T to int8: %w", i, i, ErrNotFinite)
+		}
 		return int8(s), nil
 	case float32:
+		if !isFinite(float64(s)) {
+			return 0, fmt.Errorf("unable to cast %#v of type %T to int8: %w", i, i, ErrNotFinite)
+		}
 		return int8(s), nil
+	case []byte, json.RawMessage:
+		text := byteString(i)
+		return c.ToInt8E(text)
 	case string:
-		v, err := strconv.ParseInt(s, 0, 0)
+		v, err := c.parseInt(s, 0)
 		if err == nil {
 			return int8(v), nil
 		}
@@ -430,6 +1064,15 @@ func ToInt8E(i interface{}) (int8, error) {
 			return int8(v), nil
 		}
 		return 0, fmt.Errorf("unable to cast %#v of type %T to int8", i, i)
+	case complex128, complex64:
+		v, err := complexToReal(s, "int8")
+		if err != nil {
+			return 0, err
+		}
+		return c.ToInt8E(v)
+	case *big.Int, *big.Float, *big.Rat:
+		v, err := bigToInt64(s, 8)
+		return int8(v), err
 	case bool:
 		if s {
 			return 1, nil
@@ -438,12 +1081,20 @@ func ToInt8E(i interface{}) (int8, error) {
 	case nil:
 		return 0, nil
 	default:
+		if v, ok := underlyingValue(i); ok {
+			return c.ToInt8E(v)
+		}
 		return 0, fmt.Errorf("unable to Cast %#v of type %T to int8\n", i, i)
 	}
 }
 
-// ToIntE casts an interface to an int type.
+// ToIntE calls [Caster.ToIntE] with the package options.
 func ToIntE(i interface{}) (int, error) {
+	return defaultCaster().ToIntE(i)
+}
+
+// ToIntE casts an interface to an int type.
+func (c Caster) ToIntE(i interface{}) (int, error) {
 	i = indirect(i)
 
 	switch s := i.(type) {
@@ -468,11 +1119,20 @@ func ToIntE(i interface{}) (int, error) {
 	case uint8:
 		return int(s), nil
 	case float64:
+		if !isFinite(s) {
+			return 0, fmt.Errorf("unable to cast %#v of type %T to int: %w", i, i, ErrNotFinite)
+		}
 		return int(s), nil
 	case float32:
+		if !isFinite(float64(s)) {
+			return 0, fmt.Errorf("unable to cast %#v of type %T to int: %w", i, i, ErrNotFinite)
+		}
 		return int(s), nil
+	case []byte, json.RawMessage:
+		text := byteString(i)
+		return c.ToIntE(text)
 	case string:
-		v, err := strconv.ParseInt(s, 0, 0)
+		v, err := c.parseInt(s, 0)
 		if err == nil {
 			return int(v), nil
 		}
@@ -483,6 +1143,15 @@ func ToIntE(i interface{}) (int, error) {
 			return int(v), nil
 		}
 		return 0, fmt.Errorf("unable to cast %#v of type %T to int", i, i)
+	case complex128, complex64:
+		v, err := complexToReal(s, "int")
+		if err != nil {
+			return 0, err
+		}
+		return c.ToIntE(v)
+	case *big.Int, *big.Float, *big.Rat:
+		v, err := bigToInt64(s, strconv.IntSize)
+		return int(v), err
 	case bool:
 		if s {
 			return 1, nil
@@ -491,75 +1160,122 @@ func ToIntE(i interface{}) (int, error) {
 	case nil:
 		return 0, nil
 	default:
+		if v, ok := underlyingValue(i); ok {
+			return c.ToIntE(v)
+		}
 		return 0, fmt.Errorf("unable to Cast %#v of type %T to int\n", i, i)
 	}
 }
 
-// ToUintE casts an interface to a uint type.
+// ToUintE calls [Caster.ToUintE] with the package options.
 func ToUintE(i interface{}) (uint, error) {
-	i = indirect(i)
+	return defaultCaster().ToUintE(i)
+}
 
-	switch s := i.(type) {
-	case string:
-		v, err := strconv.ParseUint(s, 0, 0)
-		if err == nil {
-			return uint(v), nil
+// ToUintE casts an interface to a uint type.
+func (c Caster) ToUintE(i interface{}) (uint, error) {
+	v, err := c.toUintE(i, strconv.IntSize, "uint")
+	return uint(v), err
+}
+
+// ToUint64E calls [Caster.ToUint64E] with the package options.
+func ToUint64E(i interface{}) (uint64, error) {
+	return defaultCaster().ToUint64E(i)
+}
+
+// ToUint64E casts an interface to a uint64 type.
+func (c Caster) ToUint64E(i interface{}) (uint64, error) {
+	return c.toUintE(i, 64, "uint64")
+}
+
+// ToUint32E calls [Caster.ToUint32E] with the package options.
+func ToUint32E(i interface{}) (uint32, error) {
+	return defaultCaster().ToUint32E(i)
+}
+
+// ToUint32E casts an interface to a uint32 type.
+func (c Caster) ToUint32E(i interface{}) (uint32, error) {
+	v, err := c.toUintE(i, 32, "uint32")
+	return uint32(v), err
+}
+
+// ToUint16E calls [Caster.ToUint16E] with the package options.
+func ToUint16E(i interface{}) (uint16, error) {
+	return defaultCaster().ToUint16E(i)
+}
+
+// ToUint16E casts an interface to a uint16 type.
+func (c Caster) ToUint16E(i interface{}) (uint16, error) {
+	v, err := c.toUintE(i, 16, "uint16")
+	return uint16(v), err
+}
+
+// ToUint8E calls [Caster.ToUint8E] with the package options.
+func ToUint8E(i interface{}) (uint8, error) {
+	return defaultCaster().ToUint8E(i)
+}
+
+// ToUint8E casts an interface to a uint8 type.
+func (c Caster) ToUint8E(i interface{}) (uint8, error) {
+	v, err := c.toUintE(i, 8, "uint8")
+	return uint8(v), err
+}
+
+// toUintE casts an interface to a uint64 that fits in an unsigned integer
+// of the given bit size, named typ in errors. Negative values fail with
+// ErrNegativeNotAllowed and values that do not fit with ErrValueOutOfRange.
+func (c Caster) toUintE(i interface{}, bitSize int, typ string) (uint64, error) {
+	i = indirect(i)
+
+	var v uint64
+	switch s := i.(type) {
+	case []byte, json.RawMessage:
+		text := byteString(i)
+		return c.toUintE(text, bitSize, typ)
+	case string:
+		n, err := c.parseUint(s, bitSize)
+		if err != nil {
+			return 0, uintParseError(i, typ, err, func() (int64, error) { return c.parseInt(s, 64) })
 		}
-		return 0, fmt.Errorf("unable to cast %#v to uint: %s", i, err)
+		return n, nil
 	case json.Number:
-		v, err := s.Int64()
-		if err == nil {
-			if v < 0 {
-				return 0, errNegativeNotAllowed
-			}
-			return uint(v), nil
+		n, err := strconv.ParseUint(s.String(), 10, bitSize)
+		if err != nil {
+			return 0, uintParseError(i, typ, err, s.Int64)
 		}
-		return 0, fmt.Errorf("unable to cast %#v to unit: %s", i, err)
+		return n, nil
 	case int:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint(s), nil
+		return intToUint(i, int64(s), bitSize, typ)
 	case int64:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint(s), nil
+		return intToUint(i, s, bitSize, typ)
 	case int32:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint(s), nil
+		return intToUint(i, int64(s), bitSize, typ)
 	case int16:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint(s), nil
+		return intToUint(i, int64(s), bitSize, typ)
 	case int8:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint(s), nil
+		return intToUint(i, int64(s), bitSize, typ)
 	case uint:
-		return s, nil
+		v = uint64(s)
 	case uint64:
-		return uint(s), nil
+		v = s
 	case uint32:
-		return uint(s), nil
+		v = uint64(s)
 	case uint16:
-		return uint(s), nil
+		v = uint64(s)
 	case uint8:
-		return uint(s), nil
+		v = uint64(s)
 	case float64:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint(s), nil
+		return floatToUint(i, s, bitSize, typ)
 	case float32:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
+		return floatToUint(i, float64(s), bitSize, typ)
+	case complex128, complex64:
+		v, err := complexToReal(s, typ)
+		if err != nil {
+			return 0, err
 		}
-		return uint(s), nil
+		return floatToUint(i, v, bitSize, typ)
+	case *big.Int, *big.Float, *big.Rat:
+		return bigToUint64(s, bitSize)
 	case bool:
 		if s {
 			return 1, nil
@@ -568,320 +1284,357 @@ func ToUintE(i interface{}) (uint, error) {
 	case nil:
 		return 0, nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint", i, i)
+		if v, ok := underlyingValue(i); ok {
+			return c.toUintE(v, bitSize, typ)
+		}
+		return 0, fmt.Errorf("unable to cast %#v of type %T to %s", i, i, typ)
+	}
+
+	if bitSize < 64 && v >= 1<<bitSize {
+		return 0, fmt.Errorf("unable to cast %#v of type %T to %s: %w", i, i, typ, ErrValueOutOfRange)
 	}
+	return v, nil
 }
 
-// ToUint64E casts an interface to a uint64 type.
-func ToUint64E(i interface{}) (uint64, error) {
+func intToUint(i interface{}, v int64, bitSize int, typ string) (uint64, error) {
+	if v < 0 {
+		return 0, fmt.Errorf("unable to cast %#v of type %T to %s: %w", i, i, typ, ErrNegativeNotAllowed)
+	}
+	if bitSize < 64 && v >= 1<<bitSize {
+		return 0, fmt.Errorf("unable to cast %#v of type %T to %s: %w", i, i, typ, ErrValueOutOfRange)
+	}
+	return uint64(v), nil
+}
+
+func floatToUint(i interface{}, v float64, bitSize int, typ string) (uint64, error) {
+	if !isFinite(v) {
+		return 0, fmt.Errorf("unable to cast %#v of type %T to %s: %w", i, i, typ, ErrNotFinite)
+	}
+	if v < 0 {
+		return 0, fmt.Errorf("unable to cast %#v of type %T to %s: %w", i, i, typ, ErrNegativeNotAllowed)
+	}
+	if v >= math.Ldexp(1, bitSize) {
+		return 0, fmt.Errorf("unable to cast %#v of type %T to %s: %w", i, i, typ, ErrValueOutOfRange)
+	}
+	return uint64(v), nil
+}
+
+// uintParseError explains why a string failed to parse as an unsigned
+// integer. As strconv.ParseUint rejects any sign, parseInt tells negative
+// numbers apart from malformed ones.
+func uintParseError(i interface{}, typ string, err error, parseInt func() (int64, error)) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return fmt.Errorf("unable to cast %#v of type %T to %s: %w", i, i, typ, ErrValueOutOfRange)
+	}
+	if n, err := parseInt(); (err == nil && n < 0) || errors.Is(err, strconv.ErrRange) {
+		return fmt.Errorf("unable to cast %#v of type %T to %s: %w", i, i, typ, ErrNegativeNotAllowed)
+	}
+	return fmt.Errorf("unable to cast %#v of type %T to %s", i, i, typ)
+}
+
+// ToBigIntE calls [Caster.ToBigIntE] with the package options.
+func ToBigIntE(i interface{}) (*big.Int, error) {
+	return defaultCaster().ToBigIntE(i)
+}
+
+// ToBigIntE casts an interface to a *big.Int type. Fractional inputs, such
+// as 12.5 or "12.5", are truncated towards zero.
+func (c Caster) ToBigIntE(i interface{}) (*big.Int, error) {
 	i = indirect(i)
 
 	switch s := i.(type) {
-	case string:
-		v, err := strconv.ParseUint(s, 0, 64)
-		if err == nil {
-			return v, nil
+	case *big.Int:
+		if s == nil {
+			return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Int", i, i)
 		}
-		return 0, fmt.Errorf("unable to cast %#v to uint64: %s", i, err)
-	case json.Number:
-		v, err := s.Int64()
-		if err == nil {
-			if v < 0 {
-				return 0, errNegativeNotAllowed
+		return new(big.Int).Set(s), nil
+	case *big.Float, *big.Rat:
+		n, err := bigToInt(s)
+		if err != nil {
+			return nil, fmt.Errorf("unable to cast %v of type %T to *big.Int", i, i)
+		}
+		return new(big.Int).Set(n), nil
+	case int, int64, int32, int16, int8:
+		return big.NewInt(c.ToInt64(s)), nil
+	case uint, uint64, uint32, uint16, uint8:
+		return new(big.Int).SetUint64(c.ToUint64(s)), nil
+	case float64, float32:
+		f := c.ToFloat64(s)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Int", i, i)
+		}
+		n, _ := big.NewFloat(f).Int(nil)
+		return n, nil
+	case []byte, json.RawMessage:
+		text := byteString(i)
+		return c.ToBigIntE(text)
+	case string:
+		if v, err := c.normalizeNumber(s); err == nil {
+			if n, ok := parseBigInt(v, 0); ok {
+				return n, nil
 			}
-			return uint64(v), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v to uint64: %s", i, err)
-	case int:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint64(s), nil
-	case int64:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint64(s), nil
-	case int32:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint64(s), nil
-	case int16:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint64(s), nil
-	case int8:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
 		}
-		return uint64(s), nil
-	case uint:
-		return uint64(s), nil
-	case uint64:
-		return s, nil
-	case uint32:
-		return uint64(s), nil
-	case uint16:
-		return uint64(s), nil
-	case uint8:
-		return uint64(s), nil
-	case float32:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint64(s), nil
-	case float64:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
+		return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Int", i, i)
+	case json.Number:
+		if n, ok := parseBigInt(s.String(), 10); ok {
+			return n, nil
 		}
-		return uint64(s), nil
+		return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Int", i, i)
 	case bool:
 		if s {
-			return 1, nil
+			return big.NewInt(1), nil
 		}
-		return 0, nil
+		return big.NewInt(0), nil
 	case nil:
-		return 0, nil
+		return big.NewInt(0), nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", i, i)
+		if v, ok := underlyingValue(i); ok {
+			return c.ToBigIntE(v)
+		}
+		return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Int", i, i)
 	}
 }
 
-// ToUint32E casts an interface to a uint32 type.
-func ToUint32E(i interface{}) (uint32, error) {
+// ToBigFloatE calls [Caster.ToBigFloatE] with the package options.
+func ToBigFloatE(i interface{}) (*big.Float, error) {
+	return defaultCaster().ToBigFloatE(i)
+}
+
+// ToBigFloatE casts an interface to a *big.Float type. Strings are parsed
+// with enough precision to hold all of their digits.
+func (c Caster) ToBigFloatE(i interface{}) (*big.Float, error) {
 	i = indirect(i)
 
 	switch s := i.(type) {
+	case *big.Float:
+		if s == nil {
+			return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Float", i, i)
+		}
+		return new(big.Float).Copy(s), nil
+	case *big.Int:
+		if s == nil {
+			return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Float", i, i)
+		}
+		return new(big.Float).SetInt(s), nil
+	case *big.Rat:
+		if s == nil {
+			return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Float", i, i)
+		}
+		return new(big.Float).SetRat(s), nil
+	case int, int64, int32, int16, int8:
+		return new(big.Float).SetInt64(c.ToInt64(s)), nil
+	case uint, uint64, uint32, uint16, uint8:
+		return new(big.Float).SetUint64(c.ToUint64(s)), nil
+	case float64, float32:
+		f := c.ToFloat64(s)
+		if math.IsNaN(f) {
+			return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Float", i, i)
+		}
+		return big.NewFloat(f), nil
+	case []byte, json.RawMessage:
+		text := byteString(i)
+		return c.ToBigFloatE(text)
 	case string:
-		v, err := strconv.ParseUint(s, 0, 32)
-		if err == nil {
-			return uint32(v), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v to uint32: %s", i, err)
-	case json.Number:
-		v, err := s.Int64()
-		if err == nil {
-			if v < 0 {
-				return 0, errNegativeNotAllowed
+		if v, err := c.normalizeNumber(s); err == nil {
+			if f, _, err := big.ParseFloat(v, 0, bigFloatPrec(len(v)), big.ToNearestEven); err == nil {
+				return f, nil
 			}
-			return uint32(v), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v to uint32: %s", i, err)
-	case int:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint32(s), nil
-	case int64:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint32(s), nil
-	case int32:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint32(s), nil
-	case int16:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint32(s), nil
-	case int8:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint32(s), nil
-	case uint:
-		return uint32(s), nil
-	case uint64:
-		return uint32(s), nil
-	case uint32:
-		return s, nil
-	case uint16:
-		return uint32(s), nil
-	case uint8:
-		return uint32(s), nil
-	case float64:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
 		}
-		return uint32(s), nil
-	case float32:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
+		return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Float", i, i)
+	case json.Number:
+		if f, _, err := big.ParseFloat(s.String(), 10, bigFloatPrec(len(s)), big.ToNearestEven); err == nil {
+			return f, nil
 		}
-		return uint32(s), nil
+		return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Float", i, i)
 	case bool:
 		if s {
-			return 1, nil
+			return big.NewFloat(1), nil
 		}
-		return 0, nil
+		return big.NewFloat(0), nil
 	case nil:
-		return 0, nil
+		return big.NewFloat(0), nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", i, i)
+		if v, ok := underlyingValue(i); ok {
+			return c.ToBigFloatE(v)
+		}
+		return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Float", i, i)
 	}
 }
 
-// ToUint16E casts an interface to a uint16 type.
-func ToUint16E(i interface{}) (uint16, error) {
+// ToBigRatE calls [Caster.ToBigRatE] with the package options.
+func ToBigRatE(i interface{}) (*big.Rat, error) {
+	return defaultCaster().ToBigRatE(i)
+}
+
+// ToBigRatE casts an interface to a *big.Rat type. Floats are converted to
+// the exact value of their binary representation.
+func (c Caster) ToBigRatE(i interface{}) (*big.Rat, error) {
 	i = indirect(i)
 
 	switch s := i.(type) {
+	case *big.Rat:
+		if s == nil {
+			return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Rat", i, i)
+		}
+		return new(big.Rat).Set(s), nil
+	case *big.Int:
+		if s == nil {
+			return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Rat", i, i)
+		}
+		return new(big.Rat).SetInt(s), nil
+	case *big.Float:
+		if s != nil && !s.IsInf() {
+			r, _ := s.Rat(nil)
+			return r, nil
+		}
+		return nil, fmt.Errorf("unable to cast %v of type %T to *big.Rat", i, i)
+	case int, int64, int32, int16, int8:
+		return new(big.Rat).SetInt64(c.ToInt64(s)), nil
+	case uint, uint64, uint32, uint16, uint8:
+		return new(big.Rat).SetUint64(c.ToUint64(s)), nil
+	case float64, float32:
+		if r := new(big.Rat).SetFloat64(c.ToFloat64(s)); r != nil {
+			return r, nil
+		}
+		return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Rat", i, i)
+	case []byte, json.RawMessage:
+		text := byteString(i)
+		return c.ToBigRatE(text)
 	case string:
-		v, err := strconv.ParseUint(s, 0, 16)
-		if err == nil {
-			return uint16(v), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v to uint16: %s", i, err)
-	case json.Number:
-		v, err := s.Int64()
-		if err == nil {
-			if v < 0 {
-				return 0, errNegativeNotAllowed
+		if v, err := c.normalizeNumber(s); err == nil {
+			if r, ok := new(big.Rat).SetString(v); ok {
+				return r, nil
 			}
-			return uint16(v), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v to uint16: %s", i, err)
-	case int:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint16(s), nil
-	case int64:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
 		}
-		return uint16(s), nil
-	case int32:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint16(s), nil
-	case int16:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint16(s), nil
-	case int8:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint16(s), nil
-	case uint:
-		return uint16(s), nil
-	case uint64:
-		return uint16(s), nil
-	case uint32:
-		return uint16(s), nil
-	case uint16:
-		return s, nil
-	case uint8:
-		return uint16(s), nil
-	case float64:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint16(s), nil
-	case float32:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
+		return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Rat", i, i)
+	case json.Number:
+		if r, ok := new(big.Rat).SetString(s.String()); ok {
+			return r, nil
 		}
-		return uint16(s), nil
+		return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Rat", i, i)
 	case bool:
 		if s {
-			return 1, nil
+			return big.NewRat(1, 1), nil
 		}
-		return 0, nil
+		return new(big.Rat), nil
 	case nil:
-		return 0, nil
+		return new(big.Rat), nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", i, i)
+		if v, ok := underlyingValue(i); ok {
+			return c.ToBigRatE(v)
+		}
+		return nil, fmt.Errorf("unable to cast %#v of type %T to *big.Rat", i, i)
 	}
 }
 
-// ToUint8E casts an interface to a uint type.
-func ToUint8E(i interface{}) (uint8, error) {
+// ToDecimalE calls [Caster.ToDecimalE] with the package options.
+func ToDecimalE(i interface{}) (Decimal, error) {
+	return defaultCaster().ToDecimalE(i)
+}
+
+// ToDecimalE casts an interface to an exact Decimal. Floats are converted
+// from the shortest decimal that represents them, so float64(19.99) gives
+// 19.99 rather than its binary approximation.
+func (c Caster) ToDecimalE(i interface{}) (Decimal, error) {
 	i = indirect(i)
 
 	switch s := i.(type) {
+	case Decimal:
+		return s, nil
+	case int, int64, int32, int16, int8:
+		return NewDecimal(c.ToInt64(s), 0), nil
+	case uint, uint64, uint32, uint16, uint8:
+		return Decimal{unscaled: new(big.Int).SetUint64(c.ToUint64(s))}, nil
+	case float64:
+		return floatToDecimal(i, s, 64)
+	case float32:
+		return floatToDecimal(i, float64(s), 32)
+	case []byte, json.RawMessage:
+		text := byteString(i)
+		return c.ToDecimalE(text)
 	case string:
-		v, err := strconv.ParseUint(s, 0, 8)
-		if err == nil {
-			return uint8(v), nil
-		}
-		return 0, fmt.Errorf("unable to cast %#v to uint8: %s", i, err)
-	case json.Number:
-		v, err := s.Int64()
-		if err == nil {
-			if v < 0 {
-				return 0, errNegativeNotAllowed
+		if v, err := c.normalizeNumber(s); err == nil {
+			if d, err := ParseDecimal(v); err == nil {
+				return d, nil
 			}
-			return uint8(v), nil
 		}
-		return 0, fmt.Errorf("unable to cast %#v to uint8: %s", i, err)
-	case int:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint8(s), nil
-	case int64:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint8(s), nil
-	case int32:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint8(s), nil
-	case int16:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint8(s), nil
-	case int8:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
+		return Decimal{}, fmt.Errorf("unable to cast %#v of type %T to Decimal", i, i)
+	case json.Number:
+		if d, err := ParseDecimal(s.String()); err == nil {
+			return d, nil
 		}
-		return uint8(s), nil
-	case uint:
-		return uint8(s), nil
-	case uint64:
-		return uint8(s), nil
-	case uint32:
-		return uint8(s), nil
-	case uint16:
-		return uint8(s), nil
-	case uint8:
-		return s, nil
-	case float64:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
+		return Decimal{}, fmt.Errorf("unable to cast %#v of type %T to Decimal", i, i)
+	case *big.Int, *big.Float, *big.Rat:
+		r, err := c.ToBigRatE(s)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("unable to cast %v of type %T to Decimal", i, i)
 		}
-		return uint8(s), nil
-	case float32:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
+		if d, ok := decimalFromRat(r); ok {
+			return d, nil
 		}
-		return uint8(s), nil
+		return Decimal{}, fmt.Errorf("unable to cast %v of type %T to Decimal: no finite decimal representation", i, i)
 	case bool:
 		if s {
-			return 1, nil
+			return NewDecimal(1, 0), nil
 		}
-		return 0, nil
+		return Decimal{}, nil
 	case nil:
-		return 0, nil
+		return Decimal{}, nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", i, i)
+		if v, ok := underlyingValue(i); ok {
+			return c.ToDecimalE(v)
+		}
+		return Decimal{}, fmt.Errorf("unable to cast %#v of type %T to Decimal", i, i)
 	}
 }
 
-// ToStringE casts an interface to a string type.
+// ToDecimalWithScaleE calls [Caster.ToDecimalWithScaleE]
+// with the package options.
+func ToDecimalWithScaleE(i interface{}, scale int32, mode RoundingMode) (Decimal, error) {
+	return defaultCaster().ToDecimalWithScaleE(i, scale, mode)
+}
+
+// ToDecimalWithScaleE casts an interface to a Decimal with the given number
+// of digits after the decimal point, rounding with the given mode.
+func (c Caster) ToDecimalWithScaleE(i interface{}, scale int32, mode RoundingMode) (Decimal, error) {
+	if r, ok := indirect(i).(*big.Rat); ok && r != nil {
+		// Rationals such as 1/3 have no exact Decimal to round.
+		return roundRat(r, scale, mode), nil
+	}
+	d, err := c.ToDecimalE(i)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return d.Round(scale, mode), nil
+}
+
+func floatToDecimal(i interface{}, f float64, bitSize int) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, fmt.Errorf("unable to cast %#v of type %T to Decimal", i, i)
+	}
+	return ParseDecimal(strconv.FormatFloat(f, 'g', -1, bitSize))
+}
+
+// ToStringWithFormatE calls [Caster.ToStringWithFormatE]
+// with the package options.
+func ToStringWithFormatE(i interface{}, format FloatFormat) (string, error) {
+	return defaultCaster().ToStringWithFormatE(i, format)
+}
+
+// ToStringWithFormatE casts an interface to a string type, formatting
+// floats with the given FloatFormat.
+func (c Caster) ToStringWithFormatE(i interface{}, format FloatFormat) (string, error) {
+	c.FloatFormat = format
+	return c.ToStringE(i)
+}
+
+// ToStringE calls [Caster.ToStringE] with the package options.
 func ToStringE(i interface{}) (string, error) {
+	return defaultCaster().ToStringE(i)
+}
+
+// ToStringE casts an interface to a string type.
+func (c Caster) ToStringE(i interface{}) (string, error) {
 	i = indirectToStringerOrError(i)
 
 	switch s := i.(type) {
@@ -890,9 +1643,13 @@ func ToStringE(i interface{}) (string, error) {
 	case bool:
 		return strconv.FormatBool(s), nil
 	case float64:
-		return strconv.FormatFloat(s, 'f', -1, 64), nil
+		return c.formatFloat(s, 64), nil
 	case float32:
-		return strconv.FormatFloat(float64(s), 'f', -1, 32), nil
+		return c.formatFloat(float64(s), 32), nil
+	case complex128:
+		return c.formatComplex(s, 128), nil
+	case complex64:
+		return c.formatComplex(complex128(s), 64), nil
 	case int:
 		return strconv.Itoa(s), nil
 	case int64:
@@ -917,6 +1674,8 @@ func ToStringE(i interface{}) (string, error) {
 		return s.String(), nil
 	case []byte:
 		return string(s), nil
+	case json.RawMessage:
+		return byteString(s), nil
 	case template.HTML:
 		return string(s), nil
 	case template.URL:
@@ -927,19 +1686,210 @@ func ToStringE(i interface{}) (string, error) {
 		return string(s), nil
 	case template.HTMLAttr:
 		return string(s), nil
+	case *big.Float:
+		if s == nil {
+			return "<nil>", nil
+		}
+		return s.Text('f', -1), nil
+	case *big.Rat:
+		if s == nil {
+			return "<nil>", nil
+		}
+		return s.RatString(), nil
 	case nil:
 		return "", nil
 	case fmt.Stringer:
 		return s.String(), nil
 	case error:
 		return s.Error(), nil
+	case encoding.TextMarshaler:
+		text, err := s.MarshalText()
+		if err != nil {
+			return "", fmt.Errorf("unable to cast %#v of type %T to string: %w", i, i, err)
+		}
+		return string(text), nil
 	default:
+		if v, ok := underlyingValue(i); ok {
+			return c.ToStringE(v)
+		}
 		return "", fmt.Errorf("unable to Cast %#v of type %T to string\n", i, i)
 	}
 }
 
-// ToStringMapStringE casts an interface to a map[string]string type.
+// ToE casts an interface to the type T, which may be a defined type such
+// as "type Port int" or "type Level string". T may also be any type with a
+// cast of its own, such as time.Time, time.Duration, Date, Decimal or
+// *big.Int. Strings are decoded with UnmarshalText if T or *T implements
+// encoding.TextUnmarshaler, as net.IP does, and copied into byte slices
+// such as json.RawMessage. A pointer type T is nil for nil and otherwise
+// points to the cast of i to its element type.
+func ToE[T any](i interface{}) (T, error) {
+	return ToWithCasterE[T](i, defaultCaster())
+}
+
+// ToWithCasterE casts an interface to the type T like ToE, with the
+// options of the given Caster.
+func ToWithCasterE[T any](i interface{}, c Caster) (T, error) {
+	var zero T
+	v, err := c.toType(i, reflect.TypeOf(&zero).Elem())
+	if err != nil {
+		return zero, err
+	}
+	// A nil interface T has no dynamic type to assert.
+	cast, _ := v.Interface().(T)
+	return cast, nil
+}
+
+// toType casts an interface to a value of the type t, using the cast for
+// t, or for the kind of t if there is none.
+func (c Caster) toType(i interface{}, t reflect.Type) (reflect.Value, error) {
+	if i != nil && reflect.TypeOf(i) == t {
+		return reflect.ValueOf(i), nil
+	}
+
+	var v interface{}
+	var err error
+	switch t {
+	case timeType:
+		v, err = c.ToTimeE(i)
+	case durationType:
+		v, err = c.ToDurationE(i)
+	case dateType:
+		v, err = c.ToDateE(i)
+	case decimalType:
+		v, err = c.ToDecimalE(i)
+	case locationType:
+		v, err = c.ToLocationE(i)
+	case bigIntType:
+		v, err = c.ToBigIntE(i)
+	case bigFloatType:
+		v, err = c.ToBigFloatE(i)
+	case bigRatType:
+		v, err = c.ToBigRatE(i)
+	default:
+		if text, ok := textOf(i); ok {
+			if v, ok, err := unmarshalText(text, t); ok {
+				if err != nil {
+					return reflect.Value{}, fmt.Errorf("unable to cast %#v of type %T to %v: %w", i, i, t, err)
+				}
+				return v, nil
+			}
+		}
+
+		switch t.Kind() {
+		case reflect.Bool:
+			v, err = c.ToBoolE(i)
+		case reflect.Int:
+			v, err = c.ToIntE(i)
+		case reflect.Int8:
+			v, err = c.ToInt8E(i)
+		case reflect.Int16:
+			v, err = c.ToInt16E(i)
+		case reflect.Int32:
+			v, err = c.ToInt32E(i)
+		case reflect.Int64:
+			v, err = c.ToInt64E(i)
+		case reflect.Uint:
+			v, err = c.ToUintE(i)
+		case reflect.Uint8:
+			v, err = c.ToUint8E(i)
+		case reflect.Uint16:
+			v, err = c.ToUint16E(i)
+		case reflect.Uint32:
+			v, err = c.ToUint32E(i)
+		case reflect.Uint64:
+			v, err = c.ToUint64E(i)
+		case reflect.Float32:
+			v, err = c.ToFloat32E(i)
+		case reflect.Float64:
+			v, err = c.ToFloat64E(i)
+		case reflect.Complex64:
+			v, err = c.ToComplex64E(i)
+		case reflect.Complex128:
+			v, err = c.ToComplex128E(i)
+		case reflect.String:
+			v, err = c.ToStringE(i)
+		case reflect.Slice:
+			// Byte slices such as json.RawMessage hold a copy of the text.
+			if text, ok := textOf(i); ok && t.Elem().Kind() == reflect.Uint8 {
+				return reflect.ValueOf(append([]byte{}, text...)).Convert(t), nil
+			}
+			return reflect.Value{}, fmt.Errorf("unable to cast %#v of type %T to %v", i, i, t)
+		case reflect.Ptr:
+			if i == nil {
+				return reflect.Zero(t), nil
+			}
+			e, err := c.toType(i, t.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			v := reflect.New(t.Elem())
+			v.Elem().Set(e)
+			return v, nil
+		case reflect.Interface:
+			if i == nil {
+				return reflect.Zero(t), nil
+			}
+			if reflect.TypeOf(i).Implements(t) {
+				return reflect.ValueOf(i).Convert(t), nil
+			}
+			fallthrough
+		default:
+			return reflect.Value{}, fmt.Errorf("unable to cast %#v of type %T to %v", i, i, t)
+		}
+	}
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(v).Convert(t), nil
+}
+
+// textOf returns the text of a string or []byte, including those of a
+// defined type.
+func textOf(i interface{}) ([]byte, bool) {
+	i = indirect(i)
+	if v, ok := underlyingValue(i); ok {
+		i = v
+	}
+	switch s := i.(type) {
+	case string:
+		return []byte(s), true
+	case []byte:
+		return s, true
+	}
+	return nil, false
+}
+
+// unmarshalText decodes text into a new value of the type t, and reports
+// whether t or *t implements encoding.TextUnmarshaler.
+func unmarshalText(text []byte, t reflect.Type) (reflect.Value, bool, error) {
+	var ptr, v reflect.Value
+	switch {
+	case reflect.PtrTo(t).Implements(textUnmarshalerType):
+		ptr = reflect.New(t)
+		v = ptr.Elem()
+	case t.Kind() == reflect.Ptr && t.Implements(textUnmarshalerType):
+		ptr = reflect.New(t.Elem())
+		v = ptr
+	default:
+		return reflect.Value{}, false, nil
+	}
+	err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText(text)
+	return v, true, err
+}
+
+// ToStringMapStringE calls [Caster.ToStringMapStringE]
+// with the package options.
 func ToStringMapStringE(i interface{}) (map[string]string, error) {
+	return defaultCaster().ToStringMapStringE(i)
+}
+
+// ToStringMapStringE casts an interface to a map[string]string type.
+func (c Caster) ToStringMapStringE(i interface{}) (map[string]string, error) {
+	toString := func(v interface{}) string {
+		s, _ := c.ToStringE(v)
+		return s
+	}
 	var m = map[string]string{}
 
 	switch v := i.(type) {
@@ -947,29 +1897,49 @@ func ToStringMapStringE(i interface{}) (map[string]string, error) {
 		return v, nil
 	case map[string]interface{}:
 		for k, val := range v {
-			m[ToString(k)] = ToString(val)
+			m[toString(k)] = toString(val)
 		}
 		return m, nil
 	case map[interface{}]string:
 		for k, val := range v {
-			m[ToString(k)] = ToString(val)
+			m[toString(k)] = toString(val)
 		}
 		return m, nil
 	case map[interface{}]interface{}:
 		for k, val := range v {
-			m[ToString(k)] = ToString(val)
+			m[toString(k)] = toString(val)
 		}
 		return m, nil
 	case string:
-		err := jsonStringToObject(v, &m)
+		if decoded, ok, err := c.decodeSingleValueMapString(v); ok {
+			if err != nil {
+				return m, err
+			}
+			return c.ToStringMapStringE(decoded)
+		}
+		err := c.jsonStringToObject(v, &m)
 		return m, err
 	default:
 		return m, fmt.Errorf("unable to cast %#v of type %T to map[string]string", i, i)
 	}
 }
 
-// ToStringMapStringSliceE casts an interface to a map[string][]string type.
+// ToStringMapStringSliceE calls [Caster.ToStringMapStringSliceE]
+// with the package options.
 func ToStringMapStringSliceE(i interface{}) (map[string][]string, error) {
+	return defaultCaster().ToStringMapStringSliceE(i)
+}
+
+// ToStringMapStringSliceE casts an interface to a map[string][]string type.
+func (c Caster) ToStringMapStringSliceE(i interface{}) (map[string][]string, error) {
+	toString := func(v interface{}) string {
+		s, _ := c.ToStringE(v)
+		return s
+	}
+	toStringSlice := func(v interface{}) []string {
+		s, _ := c.ToStringSliceE(v)
+		return s
+	}
 	var m = map[string][]string{}
 
 	switch v := i.(type) {
@@ -977,54 +1947,60 @@ func ToStringMapStringSliceE(i interface{}) (map[string][]string, error) {
 		return v, nil
 	case map[string][]interface{}:
 		for k, val := range v {
-			m[ToString(k)] = ToStringSlice(val)
+			m[toString(k)] = toStringSlice(val)
 		}
 		return m, nil
 	case map[string]string:
 		for k, val := range v {
-			m[ToString(k)] = []string{val}
+			m[toString(k)] = []string{val}
 		}
 	case map[string]interface{}:
 		for k, val := range v {
 			switch vt := val.(type) {
 			case []interface{}:
-				m[ToString(k)] = ToStringSlice(vt)
+				m[toString(k)] = toStringSlice(vt)
 			case []string:
-				m[ToString(k)] = vt
+				m[toString(k)] = vt
 			default:
-				m[ToString(k)] = []string{ToString(val)}
+				m[toString(k)] = []string{toString(val)}
 			}
 		}
 		return m, nil
 	case map[interface{}][]string:
 		for k, val := range v {
-			m[ToString(k)] = ToStringSlice(val)
+			m[toString(k)] = toStringSlice(val)
 		}
 		return m, nil
 	case map[interface{}]string:
 		for k, val := range v {
-			m[ToString(k)] = ToStringSlice(val)
+			m[toString(k)] = toStringSlice(val)
 		}
 		return m, nil
 	case map[interface{}][]interface{}:
 		for k, val := range v {
-			m[ToString(k)] = ToStringSlice(val)
+			m[toString(k)] = toStringSlice(val)
 		}
 		return m, nil
 	case map[interface{}]interface{}:
 		for k, val := range v {
-			key, err := ToStringE(k)
+			key, err := c.ToStringE(k)
 			if err != nil {
 				return m, fmt.Errorf("unable to Cast %#v of type %T to map[string]string\n", i, i)
 			}
-			value, err := ToStringSliceE(val)
+			value, err := c.ToStringSliceE(val)
 			if err != nil {
 				return m, fmt.Errorf("unable to Cast %#v of type %T to map[string]string\n", i, i)
 			}
 			m[key] = value
 		}
 	case string:
-		err := jsonStringToObject(v, &m)
+		if decoded, ok, err := c.decodeMapString(v); ok {
+			if err != nil {
+				return m, err
+			}
+			return c.ToStringMapStringSliceE(decoded)
+		}
+		err := c.jsonStringToObject(v, &m)
 		return m, err
 	default:
 		return m, fmt.Errorf("unable to Cast %#v of type %T to map[string]string\n", i, i)
@@ -1032,33 +2008,49 @@ func ToStringMapStringSliceE(i interface{}) (map[string][]string, error) {
 	return m, nil
 }
 
-// ToStringMapBoolE casts an interface to a map[string]bool type.
+// ToStringMapBoolE calls [Caster.ToStringMapBoolE] with the package options.
 func ToStringMapBoolE(i interface{}) (map[string]bool, error) {
+	return defaultCaster().ToStringMapBoolE(i)
+}
+
+// ToStringMapBoolE casts an interface to a map[string]bool type.
+func (c Caster) ToStringMapBoolE(i interface{}) (map[string]bool, error) {
 	var m = map[string]bool{}
 
 	switch v := i.(type) {
 	case map[interface{}]interface{}:
 		for k, val := range v {
-			m[ToString(k)] = ToBool(val)
+			m[c.ToString(k)] = c.ToBool(val)
 		}
 		return m, nil
 	case map[string]interface{}:
 		for k, val := range v {
-			m[ToString(k)] = ToBool(val)
+			m[c.ToString(k)] = c.ToBool(val)
 		}
 		return m, nil
 	case map[string]bool:
 		return v, nil
 	case string:
-		err := jsonStringToObject(v, &m)
+		if decoded, ok, err := c.decodeSingleValueMapString(v); ok {
+			if err != nil {
+				return m, err
+			}
+			return c.ToStringMapBoolE(decoded)
+		}
+		err := c.jsonStringToObject(v, &m)
 		return m, err
 	default:
 		return m, fmt.Errorf("unable to cast %#v of type %T to map[string]bool", i, i)
 	}
 }
 
-// ToStringMapE casts an interface to a map[string]interface{} type.
+// ToStringMapE calls [Caster.ToStringMapE] with the package options.
 func ToStringMapE(i interface{}) (map[string]interface{}, error) {
+	return defaultCaster().ToStringMapE(i)
+}
+
+// ToStringMapE casts an interface to a map[string]interface{} type.
+func (c Caster) ToStringMapE(i interface{}) (map[string]interface{}, error) {
 	var m = map[string]interface{}{}
 
 	switch v := i.(type) {
@@ -1066,20 +2058,132 @@ func ToStringMapE(i interface{}) (map[string]interface{}, error) {
 		return v, nil
 	case map[interface{}]interface{}:
 		for k, val := range v {
-			kStr, _ := ToStringE(k)
+			kStr, _ := c.ToStringE(k)
 			m[kStr] = val
 		}
 		return m, nil
 	case string:
-		err := jsonStringToObject(v, &m)
+		if decoded, ok, err := c.decodeMapString(v); ok {
+			if err != nil {
+				return m, err
+			}
+			return decoded, nil
+		}
+		err := c.jsonStringToObject(v, &m)
 		return m, err
 	default:
-		return m, fmt.Errorf("unable to Cast %#v of type %T to map[string]interface{}\n", i, i)
+		return m, fmt.Errorf("unable to Cast %#v of type %T to map[string]interface{}\n", i, i)
+	}
+}
+
+// ToStringMapDeepE calls [Caster.ToStringMapDeepE] with the package options.
+func ToStringMapDeepE(i interface{}) (map[string]interface{}, error) {
+	return defaultCaster().ToStringMapDeepE(i)
+}
+
+// ToStringMapDeepE casts an interface to a map[string]interface{} type,
+// recursively converting the nested maps to map[string]interface{} and
+// the nested slices, except []byte, to []interface{}, as needed for the
+// map[interface{}]interface{} trees of YAML decoders. Keys that do not
+// cast to strings and cycles fail with the path of the value, such as
+// "$.servers[0].ports".
+func (c Caster) ToStringMapDeepE(i interface{}) (map[string]interface{}, error) {
+	if s, ok := i.(string); ok {
+		m, err := c.ToStringMapE(s)
+		if err != nil {
+			return m, err
+		}
+		i = m
+	}
+
+	if reflect.ValueOf(indirect(i)).Kind() != reflect.Map {
+		return map[string]interface{}{}, fmt.Errorf("unable to cast %#v of type %T to map[string]interface{}", i, i)
+	}
+	v, err := c.deepNormalize(i, "$", map[deepVisit]bool{})
+	if err != nil {
+		return map[string]interface{}{}, err
+	}
+	return v.(map[string]interface{}), nil
+}
+
+// deepVisit identifies a map or slice on the path deepNormalize follows.
+type deepVisit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// deepNormalize converts the maps and slices of the tree i, at the given
+// path, for ToStringMapDeepE. The maps and slices being converted are in
+// visiting.
+func (c Caster) deepNormalize(i interface{}, path string, visiting map[deepVisit]bool) (interface{}, error) {
+	i = indirect(i)
+	v := reflect.ValueOf(i)
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			return map[string]interface{}(nil), nil
+		}
+		visit := deepVisit{v.Pointer(), v.Type()}
+		if visiting[visit] {
+			return nil, fmt.Errorf("unable to cast value at %s to map[string]interface{}: %w", path, ErrCycle)
+		}
+		visiting[visit] = true
+		defer delete(visiting, visit)
+
+		m := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			k, err := c.ToStringE(iter.Key().Interface())
+			if err != nil {
+				return nil, fmt.Errorf("unable to cast key %#v of type %T at %s to string", iter.Key().Interface(), iter.Key().Interface(), path)
+			}
+			val, err := c.deepNormalize(iter.Value().Interface(), path+"."+k, visiting)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = val
+		}
+		return m, nil
+	case reflect.Slice, reflect.Array:
+		if _, ok := i.([]byte); ok {
+			return i, nil
+		}
+		if v.Kind() == reflect.Slice {
+			if v.IsNil() {
+				return []interface{}(nil), nil
+			}
+			if v.Len() > 0 {
+				visit := deepVisit{v.Pointer(), v.Type()}
+				if visiting[visit] {
+					return nil, fmt.Errorf("unable to cast value at %s to []interface{}: %w", path, ErrCycle)
+				}
+				visiting[visit] = true
+				defer delete(visiting, visit)
+			}
+		}
+
+		a := make([]interface{}, v.Len())
+		for j := range a {
+			val, err := c.deepNormalize(v.Index(j).Interface(), fmt.Sprintf("%s[%d]", path, j), visiting)
+			if err != nil {
+				return nil, err
+			}
+			a[j] = val
+		}
+		return a, nil
+	default:
+		return i, nil
 	}
 }
 
-// ToStringMapIntE casts an interface to a map[string]int{} type.
+// ToStringMapIntE calls [Caster.ToStringMapIntE] with the package options.
 func ToStringMapIntE(i interface{}) (map[string]int, error) {
+	return defaultCaster().ToStringMapIntE(i)
+}
+
+// ToStringMapIntE casts an interface to a map[string]int{} type.
+func (c Caster) ToStringMapIntE(i interface{}) (map[string]int, error) {
 	var m = map[string]int{}
 	if i == nil {
 		return m, fmt.Errorf("unable to cast %#v of type %T to map[string]int", i, i)
@@ -1088,18 +2192,24 @@ func ToStringMapIntE(i interface{}) (map[string]int, error) {
 	switch v := i.(type) {
 	case map[interface{}]interface{}:
 		for k, val := range v {
-			m[ToString(k)] = ToInt(val)
+			m[c.ToString(k)] = c.ToInt(val)
 		}
 		return m, nil
 	case map[string]interface{}:
 		for k, val := range v {
-			m[k] = ToInt(val)
+			m[k] = c.ToInt(val)
 		}
 		return m, nil
 	case map[string]int:
 		return v, nil
 	case string:
-		err := jsonStringToObject(v, &m)
+		if decoded, ok, err := c.decodeSingleValueMapString(v); ok {
+			if err != nil {
+				return m, err
+			}
+			return c.ToStringMapIntE(decoded)
+		}
+		err := c.jsonStringToObject(v, &m)
 		return m, err
 	}
 
@@ -1110,7 +2220,7 @@ func ToStringMapIntE(i interface{}) (map[string]int, error) {
 	mVal := reflect.ValueOf(m)
 	v := reflect.ValueOf(i)
 	for _, keyVal := range v.MapKeys() {
-		val, err := ToIntE(v.MapIndex(keyVal).Interface())
+		val, err := c.ToIntE(v.MapIndex(keyVal).Interface())
 		if err != nil {
 			return m, fmt.Errorf("unable to cast %#v of type %T to map[string]int", i, i)
 		}
@@ -1119,8 +2229,14 @@ func ToStringMapIntE(i interface{}) (map[string]int, error) {
 	return m, nil
 }
 
-// ToStringMapInt64E casts an interface to a map[string]int64{} type.
+// ToStringMapInt64E calls [Caster.ToStringMapInt64E]
+// with the package options.
 func ToStringMapInt64E(i interface{}) (map[string]int64, error) {
+	return defaultCaster().ToStringMapInt64E(i)
+}
+
+// ToStringMapInt64E casts an interface to a map[string]int64{} type.
+func (c Caster) ToStringMapInt64E(i interface{}) (map[string]int64, error) {
 	var m = map[string]int64{}
 	if i == nil {
 		return m, fmt.Errorf("unable to cast %#v of type %T to map[string]int64", i, i)
@@ -1129,18 +2245,24 @@ func ToStringMapInt64E(i interface{}) (map[string]int64, error) {
 	switch v := i.(type) {
 	case map[interface{}]interface{}:
 		for k, val := range v {
-			m[ToString(k)] = ToInt64(val)
+			m[c.ToString(k)] = c.ToInt64(val)
 		}
 		return m, nil
 	case map[string]interface{}:
 		for k, val := range v {
-			m[k] = ToInt64(val)
+			m[k] = c.ToInt64(val)
 		}
 		return m, nil
 	case map[string]int64:
 		return v, nil
 	case string:
-		err := jsonStringToObject(v, &m)
+		if decoded, ok, err := c.decodeSingleValueMapString(v); ok {
+			if err != nil {
+				return m, err
+			}
+			return c.ToStringMapInt64E(decoded)
+		}
+		err := c.jsonStringToObject(v, &m)
 		return m, err
 	}
 
@@ -1151,7 +2273,7 @@ func ToStringMapInt64E(i interface{}) (map[string]int64, error) {
 	mVal := reflect.ValueOf(m)
 	v := reflect.ValueOf(i)
 	for _, keyVal := range v.MapKeys() {
-		val, err := ToInt64E(v.MapIndex(keyVal).Interface())
+		val, err := c.ToInt64E(v.MapIndex(keyVal).Interface())
 		if err != nil {
 			return m, fmt.Errorf("unable to cast %#v of type %T to map[string]int64", i, i)
 		}
@@ -1160,8 +2282,14 @@ func ToStringMapInt64E(i interface{}) (map[string]int64, error) {
 	return m, nil
 }
 
-// ToSliceE casts an interface to a []interface{} type.
+// ToSliceE calls [Caster.ToSliceE] with the package options.
 func ToSliceE(i interface{}) ([]interface{}, error) {
+	return defaultCaster().ToSliceE(i)
+}
+
+// ToSliceE casts an interface to a []interface{} type. JSON arrays keep
+// their numbers as json.Number, as in the typed slice casts.
+func (c Caster) ToSliceE(i interface{}) ([]interface{}, error) {
 	var s []interface{}
 
 	switch v := i.(type) {
@@ -1172,13 +2300,30 @@ func ToSliceE(i interface{}) ([]interface{}, error) {
 			s = append(s, u)
 		}
 		return s, nil
+	case string:
+		if elems, ok := jsonArrayElements(v); ok {
+			return elems, nil
+		}
+		parts, err := c.splitString(v)
+		if err != nil {
+			return s, fmt.Errorf("unable to cast %#v of type %T to []interface{}: %w", i, i, err)
+		}
+		for _, u := range parts {
+			s = append(s, u)
+		}
+		return s, nil
 	default:
 		return s, fmt.Errorf("unable to Cast %#v of type %T to []interface{}\n", i, i)
 	}
 }
 
-// ToBoolSliceE casts an interface to a []bool type.
+// ToBoolSliceE calls [Caster.ToBoolSliceE] with the package options.
 func ToBoolSliceE(i interface{}) ([]bool, error) {
+	return defaultCaster().ToBoolSliceE(i)
+}
+
+// ToBoolSliceE casts an interface to a []bool type.
+func (c Caster) ToBoolSliceE(i interface{}) ([]bool, error) {
 	if i == nil {
 		return []bool{}, fmt.Errorf("unable to Cast %#v of type %T to []bool\n", i, i)
 	}
@@ -1186,6 +2331,15 @@ func ToBoolSliceE(i interface{}) ([]bool, error) {
 	switch v := i.(type) {
 	case []bool:
 		return v, nil
+	case string:
+		if elems, ok := jsonArrayElements(v); ok {
+			return c.ToBoolSliceE(elems)
+		}
+		parts, err := c.splitString(v)
+		if err != nil {
+			return []bool{}, fmt.Errorf("unable to cast %#v of type %T to []bool: %w", i, i, err)
+		}
+		return c.ToBoolSliceE(parts)
 	}
 
 	kind := reflect.TypeOf(i).Kind()
@@ -1194,9 +2348,9 @@ func ToBoolSliceE(i interface{}) ([]bool, error) {
 		s := reflect.ValueOf(i)
 		a := make([]bool, s.Len())
 		for j := 0; j < s.Len(); j++ {
-			val, err := ToBoolE(s.Index(j).Interface())
+			val, err := c.ToBoolE(s.Index(j).Interface())
 			if err != nil {
-				return []bool{}, fmt.Errorf("unable to Cast %#v of type %T to []bool\n", i, i)
+				return []bool{}, fmt.Errorf("unable to cast %#v of type %T to []bool: index %d: %w", i, i, j, err)
 			}
 			a[j] = val
 		}
@@ -1206,58 +2360,73 @@ func ToBoolSliceE(i interface{}) ([]bool, error) {
 	}
 }
 
-// ToStringSliceE casts an interface to a []string type.
+// ToStringSliceE calls [Caster.ToStringSliceE] with the package options.
 func ToStringSliceE(i interface{}) ([]string, error) {
+	return defaultCaster().ToStringSliceE(i)
+}
+
+// ToStringSliceE casts an interface to a []string type.
+func (c Caster) ToStringSliceE(i interface{}) ([]string, error) {
+	toString := func(v interface{}) string {
+		s, _ := c.ToStringE(v)
+		return s
+	}
 	var a []string
 
 	switch v := i.(type) {
 	case []interface{}:
 		for _, u := range v {
-			s, _ := ToStringE(u)
-			a = append(a, s)
+			a = append(a, toString(u))
 		}
 		return a, nil
 	case []string:
 		return v, nil
 	case []int8:
 		for _, u := range v {
-			a = append(a, ToString(u))
+			a = append(a, toString(u))
 		}
 		return a, nil
 	case []int:
 		for _, u := range v {
-			a = append(a, ToString(u))
+			a = append(a, toString(u))
 		}
 		return a, nil
 	case []int32:
 		for _, u := range v {
-			a = append(a, ToString(u))
+			a = append(a, toString(u))
 		}
 		return a, nil
 	case []int64:
 		for _, u := range v {
-			a = append(a, ToString(u))
+			a = append(a, toString(u))
 		}
 		return a, nil
 	case []float32:
 		for _, u := range v {
-			a = append(a, ToString(u))
+			a = append(a, toString(u))
 		}
 		return a, nil
 	case []float64:
 		for _, u := range v {
-			a = append(a, ToString(u))
+			a = append(a, toString(u))
 		}
 		return a, nil
 	case string:
-		return strings.Fields(v), nil
+		if elems, ok := jsonArrayElements(v); ok {
+			return c.ToStringSliceE(elems)
+		}
+		parts, err := c.splitString(v)
+		if err != nil {
+			return a, fmt.Errorf("unable to cast %#v of type %T to []string: %w", i, i, err)
+		}
+		return parts, nil
 	case []error:
 		for _, err := range i.([]error) {
 			a = append(a, err.Error())
 		}
 		return a, nil
 	case interface{}:
-		str, err := ToStringE(v)
+		str, err := c.ToStringE(v)
 		if err != nil {
 			return a, fmt.Errorf("unable to Cast %#v of type %T to []string\n", i, i)
 		}
@@ -1267,8 +2436,13 @@ func ToStringSliceE(i interface{}) ([]string, error) {
 	}
 }
 
-// ToIntSliceE casts an interface to a []int type.
+// ToIntSliceE calls [Caster.ToIntSliceE] with the package options.
 func ToIntSliceE(i interface{}) ([]int, error) {
+	return defaultCaster().ToIntSliceE(i)
+}
+
+// ToIntSliceE casts an interface to a []int type.
+func (c Caster) ToIntSliceE(i interface{}) ([]int, error) {
 	if i == nil {
 		return []int{}, fmt.Errorf("unable to Cast %#v of type %T to []int\n", i, i)
 	}
@@ -1276,6 +2450,15 @@ func ToIntSliceE(i interface{}) ([]int, error) {
 	switch v := i.(type) {
 	case []int:
 		return v, nil
+	case string:
+		if elems, ok := jsonArrayElements(v); ok {
+			return c.ToIntSliceE(elems)
+		}
+		parts, err := c.splitString(v)
+		if err != nil {
+			return []int{}, fmt.Errorf("unable to cast %#v of type %T to []int: %w", i, i, err)
+		}
+		return c.ToIntSliceE(parts)
 	}
 
 	kind := reflect.TypeOf(i).Kind()
@@ -1284,9 +2467,9 @@ func ToIntSliceE(i interface{}) ([]int, error) {
 		s := reflect.ValueOf(i)
 		a := make([]int, s.Len())
 		for j := 0; j < s.Len(); j++ {
-			val, err := ToIntE(s.Index(j).Interface())
+			val, err := c.ToIntE(s.Index(j).Interface())
 			if err != nil {
-				return []int{}, fmt.Errorf("unable to Cast %#v of type %T to []int\n", i, i)
+				return []int{}, fmt.Errorf("unable to cast %#v of type %T to []int: index %d: %w", i, i, j, err)
 			}
 			a[j] = val
 		}
@@ -1296,8 +2479,13 @@ func ToIntSliceE(i interface{}) ([]int, error) {
 	}
 }
 
-// ToDurationSliceE casts an interface to a []time.Duration type.
+// ToDurationSliceE calls [Caster.ToDurationSliceE] with the package options.
 func ToDurationSliceE(i interface{}) ([]time.Duration, error) {
+	return defaultCaster().ToDurationSliceE(i)
+}
+
+// ToDurationSliceE casts an interface to a []time.Duration type.
+func (c Caster) ToDurationSliceE(i interface{}) ([]time.Duration, error) {
 	if i == nil {
 		return []time.Duration{}, fmt.Errorf("unable to cast %#v of type %T to []time.Duration", i, i)
 	}
@@ -1305,6 +2493,15 @@ func ToDurationSliceE(i interface{}) ([]time.Duration, error) {
 	switch v := i.(type) {
 	case []time.Duration:
 		return v, nil
+	case string:
+		if elems, ok := jsonArrayElements(v); ok {
+			return c.ToDurationSliceE(elems)
+		}
+		parts, err := c.splitString(v)
+		if err != nil {
+			return []time.Duration{}, fmt.Errorf("unable to cast %#v of type %T to []time.Duration: %w", i, i, err)
+		}
+		return c.ToDurationSliceE(parts)
 	}
 
 	kind := reflect.TypeOf(i).Kind()
@@ -1313,9 +2510,9 @@ func ToDurationSliceE(i interface{}) ([]time.Duration, error) {
 		s := reflect.ValueOf(i)
 		a := make([]time.Duration, s.Len())
 		for j := 0; j < s.Len(); j++ {
-			val, err := ToDurationE(s.Index(j).Interface())
+			val, err := c.ToDurationE(s.Index(j).Interface())
 			if err != nil {
-				return []time.Duration{}, fmt.Errorf("unable to cast %#v of type %T to []time.Duration", i, i)
+				return []time.Duration{}, fmt.Errorf("unable to cast %#v of type %T to []time.Duration: index %d: %w", i, i, j, err)
 			}
 			a[j] = val
 		}
@@ -1325,18 +2522,158 @@ func ToDurationSliceE(i interface{}) ([]time.Duration, error) {
 	}
 }
 
+// ToSliceOfStringMapE calls [Caster.ToSliceOfStringMapE]
+// with the package options.
+func ToSliceOfStringMapE(i interface{}) ([]map[string]interface{}, error) {
+	return defaultCaster().ToSliceOfStringMapE(i)
+}
+
+// ToSliceOfStringMapE casts an interface to a []map[string]interface{}
+// type. It accepts slices and arrays, other than []byte, and the inputs of
+// ToSliceE, whose elements cast with ToStringMapE.
+func (c Caster) ToSliceOfStringMapE(i interface{}) ([]map[string]interface{}, error) {
+	elems, err := c.sliceElements(i)
+	if err != nil {
+		return []map[string]interface{}{}, fmt.Errorf("unable to cast %#v of type %T to []map[string]interface{}", i, i)
+	}
+	a := make([]map[string]interface{}, len(elems))
+	for j, elem := range elems {
+		if a[j], err = c.ToStringMapE(elem); err != nil {
+			return []map[string]interface{}{}, fmt.Errorf("unable to cast %#v of type %T to []map[string]interface{}: index %d: %w", i, i, j, err)
+		}
+	}
+	return a, nil
+}
+
+// ToSliceOfStringMapStringE calls [Caster.ToSliceOfStringMapStringE]
+// with the package options.
+func ToSliceOfStringMapStringE(i interface{}) ([]map[string]string, error) {
+	return defaultCaster().ToSliceOfStringMapStringE(i)
+}
+
+// ToSliceOfStringMapStringE casts an interface to a []map[string]string
+// type. It accepts slices and arrays, other than []byte, and the inputs of
+// ToSliceE, whose elements cast with ToStringMapStringE.
+func (c Caster) ToSliceOfStringMapStringE(i interface{}) ([]map[string]string, error) {
+	elems, err := c.sliceElements(i)
+	if err != nil {
+		return []map[string]string{}, fmt.Errorf("unable to cast %#v of type %T to []map[string]string", i, i)
+	}
+	a := make([]map[string]string, len(elems))
+	for j, elem := range elems {
+		if a[j], err = c.ToStringMapStringE(elem); err != nil {
+			return []map[string]string{}, fmt.Errorf("unable to cast %#v of type %T to []map[string]string: index %d: %w", i, i, j, err)
+		}
+	}
+	return a, nil
+}
+
+// ToStringMapSliceE calls [Caster.ToStringMapSliceE]
+// with the package options.
+func ToStringMapSliceE(i interface{}) (map[string][]interface{}, error) {
+	return defaultCaster().ToStringMapSliceE(i)
+}
+
+// ToStringMapSliceE casts an interface to a map[string][]interface{} type.
+// It accepts maps and the strings ToStringMapE decodes, whose values are
+// slices or arrays, other than []byte, or cast with ToSliceE.
+func (c Caster) ToStringMapSliceE(i interface{}) (map[string][]interface{}, error) {
+	entries, err := c.stringMapEntries(i)
+	if err != nil {
+		return map[string][]interface{}{}, fmt.Errorf("unable to cast %#v of type %T to map[string][]interface{}: %w", i, i, err)
+	}
+	m := make(map[string][]interface{}, len(entries))
+	for k, val := range entries {
+		if m[k], err = c.sliceElements(val); err != nil {
+			return map[string][]interface{}{}, fmt.Errorf("unable to cast %#v of type %T to map[string][]interface{}: key %q: %w", i, i, k, err)
+		}
+	}
+	return m, nil
+}
+
+// ToStringMapIntSliceE calls [Caster.ToStringMapIntSliceE]
+// with the package options.
+func ToStringMapIntSliceE(i interface{}) (map[string][]int, error) {
+	return defaultCaster().ToStringMapIntSliceE(i)
+}
+
+// ToStringMapIntSliceE casts an interface to a map[string][]int type. It
+// accepts maps and the strings ToStringMapE decodes, whose values cast with
+// ToIntSliceE.
+func (c Caster) ToStringMapIntSliceE(i interface{}) (map[string][]int, error) {
+	entries, err := c.stringMapEntries(i)
+	if err != nil {
+		return map[string][]int{}, fmt.Errorf("unable to cast %#v of type %T to map[string][]int: %w", i, i, err)
+	}
+	m := make(map[string][]int, len(entries))
+	for k, val := range entries {
+		if m[k], err = c.ToIntSliceE(val); err != nil {
+			return map[string][]int{}, fmt.Errorf("unable to cast %#v of type %T to map[string][]int: key %q: %w", i, i, k, err)
+		}
+	}
+	return m, nil
+}
+
+// sliceElements returns the elements of a slice or array other than a
+// []byte, or else casts i with ToSliceE.
+func (c Caster) sliceElements(i interface{}) ([]interface{}, error) {
+	v := reflect.ValueOf(i)
+	if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || v.Type().Elem().Kind() == reflect.Uint8 {
+		return c.ToSliceE(i)
+	}
+	s := make([]interface{}, v.Len())
+	for j := range s {
+		s[j] = v.Index(j).Interface()
+	}
+	return s, nil
+}
+
+// stringMapEntries returns the entries of any map, or of the map a string
+// decodes to, with their keys cast to strings.
+func (c Caster) stringMapEntries(i interface{}) (map[string]interface{}, error) {
+	if s, ok := i.(string); ok {
+		return c.ToStringMapE(s)
+	}
+
+	v := reflect.ValueOf(indirect(i))
+	if v.Kind() != reflect.Map {
+		return nil, errors.New("not a map")
+	}
+	m := make(map[string]interface{}, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		k, err := c.ToStringE(iter.Key().Interface())
+		if err != nil {
+			return nil, fmt.Errorf("key %#v: %w", iter.Key().Interface(), err)
+		}
+		m[k] = iter.Value().Interface()
+	}
+	return m, nil
+}
+
+// StringToDate calls [Caster.StringToDate] with the package options.
+func StringToDate(s string) (time.Time, error) {
+	return defaultCaster().StringToDate(s)
+}
+
 // StringToDate attempts to parse a string into a time.Time type using a
 // predefined list of formats.  If no suitable format is found, an error is
 // returned.
-func StringToDate(s string) (time.Time, error) {
-	return parseDateWith(s, time.UTC, timeFormats)
+func (c Caster) StringToDate(s string) (time.Time, error) {
+	return c.parseDateWith(s, time.UTC, timeFormats)
+}
+
+// StringToDateInDefaultLocation calls [Caster.StringToDateInDefaultLocation]
+// with the package options.
+func StringToDateInDefaultLocation(a string, location *time.Location) (time.Time, error) {
+	return defaultCaster().StringToDateInDefaultLocation(a, location)
 }
 
 // StringToDateInDefaultLocation casts an empty interface to a time.Time,
 // interpreting inputs without a timezone to be in the given location,
 // or the local timezone if nil.
-func StringToDateInDefaultLocation(a string, location *time.Location) (time.Time, error) {
-	return parseDateWith(a, location, timeFormats)
+func (c Caster) StringToDateInDefaultLocation(a string, location *time.Location) (time.Time, error) {
+	return c.parseDateWith(a, location, timeFormats)
 }
 
 type timeFormatType int
@@ -1387,15 +2724,241 @@ var (
 		{time.StampMicro, timeFormatTimeOnly},
 		{time.StampNano, timeFormatTimeOnly},
 	}
+
+	// dateFormats are the timeFormats that carry a date.
+	dateFormats = func() []timeFormat {
+		var formats []timeFormat
+		for _, format := range timeFormats {
+			if format.typ != timeFormatTimeOnly {
+				formats = append(formats, format)
+			}
+		}
+		return formats
+	}()
 )
 
-func parseDateWith(s string, location *time.Location, formats []timeFormat) (d time.Time, e error) {
+// DefaultTimezoneAbbreviations maps commonly seen timezone abbreviations to
+// the IANA location they usually refer to. Abbreviations are ambiguous, so
+// this table is not used unless passed to SetTimezoneAbbreviations or
+// LoadTimezoneAbbreviations.
+var DefaultTimezoneAbbreviations = map[string]string{
+	"UTC":  "UTC",
+	"GMT":  "UTC",
+	"PST":  "America/Los_Angeles",
+	"PDT":  "America/Los_Angeles",
+	"MST":  "America/Denver",
+	"MDT":  "America/Denver",
+	"CST":  "America/Chicago",
+	"CDT":  "America/Chicago",
+	"EST":  "America/New_York",
+	"EDT":  "America/New_York",
+	"AKST": "America/Anchorage",
+	"AKDT": "America/Anchorage",
+	"HST":  "Pacific/Honolulu",
+	"BST":  "Europe/London",
+	"WET":  "Europe/Lisbon",
+	"WEST": "Europe/Lisbon",
+	"CET":  "Europe/Berlin",
+	"CEST": "Europe/Berlin",
+	"EET":  "Europe/Athens",
+	"EEST": "Europe/Athens",
+	"MSK":  "Europe/Moscow",
+	"IST":  "Asia/Kolkata",
+	"JST":  "Asia/Tokyo",
+	"KST":  "Asia/Seoul",
+	"AEST": "Australia/Sydney",
+	"AEDT": "Australia/Sydney",
+	"NZST": "Pacific/Auckland",
+	"NZDT": "Pacific/Auckland",
+}
+
+var timezoneAbbreviations atomic.Pointer[map[string]*time.Location]
+
+// SetTimezoneAbbreviations enables resolution of the zone abbreviation in
+// inputs with a named timezone only (RFC1123, RFC822, RFC850 and UnixDate)
+// using the given abbreviation to IANA location name mapping. Passing nil
+// disables the resolution, which is the default; such inputs are then
+// interpreted in the default location. This applies to the package
+// functions; a Caster has its own TimezoneAbbreviations.
+func SetTimezoneAbbreviations(abbreviations map[string]string) error {
+	if abbreviations == nil {
+		timezoneAbbreviations.Store(nil)
+		return nil
+	}
+
+	locations, err := LoadTimezoneAbbreviations(abbreviations)
+	if err != nil {
+		return err
+	}
+	timezoneAbbreviations.Store(&locations)
+	return nil
+}
+
+// LoadTimezoneAbbreviations loads the locations of an abbreviation to IANA
+// location name mapping such as DefaultTimezoneAbbreviations, for the
+// TimezoneAbbreviations of a Caster.
+func LoadTimezoneAbbreviations(abbreviations map[string]string) (map[string]*time.Location, error) {
+	locations := make(map[string]*time.Location, len(abbreviations))
+	for abbr, name := range abbreviations {
+		loc, err := loadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load location %q for timezone abbreviation %q: %s", name, abbr, err)
+		}
+		locations[abbr] = loc
+	}
+	return locations, nil
+}
+
+// timezoneAbbreviationTime returns the time t, parsed with a zone
+// abbreviation, in the location c has for the abbreviation. The bool
+// reports whether there is one.
+//
+// The abbreviation decides the offset if the location uses it: "PST" and
+// "PDT" pick the first and second 1:30 of the day Los Angeles falls back,
+// and "PDT" in January keeps the offset -0700 in a fixed zone named PDT,
+// since Los Angeles is on PST then. An abbreviation the location never
+// uses, such as "GMT" for UTC, is an alias, and t gets the offset the
+// location has at that time.
+func (c Caster) timezoneAbbreviationTime(t time.Time) (time.Time, bool) {
+	name, _ := t.Zone()
+	loc := c.TimezoneAbbreviations[name]
+	if loc == nil {
+		return t, false
+	}
+
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	wall := time.Date(year, month, day, hour, min, sec, t.Nanosecond(), time.UTC)
+	offset, ok := zoneAbbreviationOffset(wall, name, loc)
+	if !ok {
+		return time.Date(year, month, day, hour, min, sec, t.Nanosecond(), loc), true
+	}
+	at := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+	if n, o := at.Zone(); n != name || o != offset {
+		at = at.In(time.FixedZone(name, offset))
+	}
+	return at, true
+}
+
+// zoneAbbreviationOffset returns the offset in seconds east of UTC that
+// loc has while it uses the zone abbreviation name, looking for the use
+// nearest to the wall clock time. The bool reports whether loc uses name
+// within half a year of it.
+func zoneAbbreviationOffset(wall time.Time, name string, loc *time.Location) (int, bool) {
+	const day = 24 * time.Hour
+	probes := []time.Duration{0, -day, day}
+	for months := 1; months <= 6; months++ {
+		probes = append(probes, -time.Duration(months)*30*day, time.Duration(months)*30*day)
+	}
+	for _, probe := range probes {
+		if n, offset := wall.Add(probe).In(loc).Zone(); n == name {
+			return offset, true
+		}
+	}
+	return 0, false
+}
+
+// locationCache holds the locations returned by loadLocation and
+// offsetToLocation, keyed by name or offset, as loading a location reads
+// the timezone database.
+var locationCache sync.Map
+
+// loadLocation is a cached time.LoadLocation.
+func loadLocation(name string) (*time.Location, error) {
+	if loc, ok := locationCache.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	cached, _ := locationCache.LoadOrStore(name, loc)
+	return cached.(*time.Location), nil
+}
+
+// offsetToLocation returns a fixed zone for the given offset in seconds
+// east of UTC.
+func offsetToLocation(offset int64) (*time.Location, error) {
+	if offset == 0 {
+		return time.UTC, nil
+	}
+	if offset <= -24*60*60 || offset >= 24*60*60 {
+		return nil, fmt.Errorf("unable to cast offset %d to *time.Location: out of range", offset)
+	}
+	if loc, ok := locationCache.Load(offset); ok {
+		return loc.(*time.Location), nil
+	}
+
+	sign, abs := '+', offset
+	if offset < 0 {
+		sign, abs = '-', -offset
+	}
+	name := fmt.Sprintf("UTC%c%02d:%02d", sign, abs/3600, abs%3600/60)
+	if abs%60 != 0 {
+		name += fmt.Sprintf(":%02d", abs%60)
+	}
+	loc, _ := locationCache.LoadOrStore(offset, time.FixedZone(name, int(offset)))
+	return loc.(*time.Location), nil
+}
+
+// stringToLocation parses an IANA location name or a fixed offset such as
+// "+05:30", "-0800", "UTC+2" or "GMT-03:00".
+func stringToLocation(s string) (*time.Location, error) {
+	s = strings.TrimSpace(s)
+	switch strings.ToUpper(s) {
+	case "", "UTC", "GMT", "Z":
+		return time.UTC, nil
+	case "LOCAL":
+		return time.Local, nil
+	}
+
+	offset := s
+	for _, prefix := range []string{"UTC", "GMT"} {
+		if len(offset) > len(prefix) && strings.EqualFold(offset[:len(prefix)], prefix) {
+			offset = offset[len(prefix):]
+			break
+		}
+	}
+	if offset[0] != '+' && offset[0] != '-' {
+		return loadLocation(s)
+	}
+
+	hours, minutes := offset[1:], ""
+	if i := strings.IndexByte(hours, ':'); i >= 0 {
+		hours, minutes = hours[:i], hours[i+1:]
+	} else if len(hours) == 4 {
+		hours, minutes = hours[:2], hours[2:]
+	}
+	h, err := strconv.ParseUint(hours, 10, 8)
+	if err != nil || len(hours) > 2 {
+		return nil, fmt.Errorf("invalid offset %q", s)
+	}
+	var m uint64
+	if minutes != "" {
+		if m, err = strconv.ParseUint(minutes, 10, 8); err != nil || len(minutes) != 2 || m >= 60 {
+			return nil, fmt.Errorf("invalid offset %q", s)
+		}
+	}
+
+	seconds := int64(h*3600 + m*60)
+	if offset[0] == '-' {
+		seconds = -seconds
+	}
+	return offsetToLocation(seconds)
+}
+
+func (c Caster) parseDateWith(s string, location *time.Location, formats []timeFormat) (d time.Time, e error) {
 	for _, format := range formats {
 		if d, e = time.Parse(format.format, s); e == nil {
 			// Some time formats have a zone name, but no offset, so it gets
 			// put in that zone name (not the default one passed in to us), but
 			// without that zone's offset. So set the location manually.
 			if format.typ <= timeFormatNamedTimezone {
+				if format.typ == timeFormatNamedTimezone {
+					if t, ok := c.timezoneAbbreviationTime(d); ok {
+						return t, nil
+					}
+				}
 				if location == nil {
 					location = time.Local
 				}
@@ -1415,13 +2978,227 @@ func indirect(a interface{}) interface{} {
 	}
 	if t := reflect.TypeOf(a); t.Kind() != reflect.Ptr {
 		// Avoid creating a reflect.Value if it's not a pointer.
-		return a
+		return driverValue(a)
 	}
 	v := reflect.ValueOf(a)
-	for v.Kind() == reflect.Ptr && !v.IsNil() {
+	for v.Kind() == reflect.Ptr && !v.IsNil() && !isBigNumber(v.Type()) {
 		v = v.Elem()
 	}
-	return v.Interface()
+	return driverValue(v.Interface())
+}
+
+// byteString returns the text of a []byte or json.RawMessage, which the
+// casts parse like a string. A JSON string in a json.RawMessage is
+// unquoted. The text is a copy, since errors may hold it after the caller
+// has reused the bytes.
+func byteString(i interface{}) string {
+	switch v := i.(type) {
+	case []byte:
+		return string(v)
+	case json.RawMessage:
+		var s string
+		if len(v) > 0 && v[0] == '"' && json.Unmarshal(v, &s) == nil {
+			return s
+		}
+		return string(v)
+	}
+	return ""
+}
+
+// driverValue unwraps a driver.Valuer such as sql.NullInt64 into its
+// database value, which is nil for invalid sql.Null* values.
+func driverValue(a interface{}) interface{} {
+	valuer, ok := a.(driver.Valuer)
+	if !ok {
+		return a
+	}
+	if v := reflect.ValueOf(a); v.Kind() == reflect.Ptr && v.IsNil() {
+		return a
+	}
+	if v, err := valuer.Value(); err == nil {
+		return v
+	}
+	return a
+}
+
+// kindTypes maps the bool, numeric and string kinds to their predeclared
+// types.
+var kindTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:       reflect.TypeOf(false),
+	reflect.Int:        reflect.TypeOf(int(0)),
+	reflect.Int8:       reflect.TypeOf(int8(0)),
+	reflect.Int16:      reflect.TypeOf(int16(0)),
+	reflect.Int32:      reflect.TypeOf(int32(0)),
+	reflect.Int64:      reflect.TypeOf(int64(0)),
+	reflect.Uint:       reflect.TypeOf(uint(0)),
+	reflect.Uint8:      reflect.TypeOf(uint8(0)),
+	reflect.Uint16:     reflect.TypeOf(uint16(0)),
+	reflect.Uint32:     reflect.TypeOf(uint32(0)),
+	reflect.Uint64:     reflect.TypeOf(uint64(0)),
+	reflect.Float32:    reflect.TypeOf(float32(0)),
+	reflect.Float64:    reflect.TypeOf(float64(0)),
+	reflect.Complex64:  reflect.TypeOf(complex64(0)),
+	reflect.Complex128: reflect.TypeOf(complex128(0)),
+	reflect.String:     reflect.TypeOf(""),
+}
+
+// underlyingValue converts a value of a defined type such as
+// "type Port int" to the predeclared type of its kind, and reports whether
+// it did. The scalar casts fall back to it for types they do not know.
+func underlyingValue(i interface{}) (interface{}, bool) {
+	v := reflect.ValueOf(i)
+	if !v.IsValid() {
+		return nil, false
+	}
+	t, ok := kindTypes[v.Kind()]
+	if !ok || v.Type() == t {
+		return nil, false
+	}
+	return v.Convert(t).Interface(), true
+}
+
+var (
+	bigIntType   = reflect.TypeOf((*big.Int)(nil))
+	bigFloatType = reflect.TypeOf((*big.Float)(nil))
+	bigRatType   = reflect.TypeOf((*big.Rat)(nil))
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	dateType     = reflect.TypeOf(Date{})
+	decimalType  = reflect.TypeOf(Decimal{})
+	locationType = reflect.TypeOf((*time.Location)(nil))
+
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// isBigNumber reports whether t is one of the math/big number types, which
+// are only ever used through pointers.
+func isBigNumber(t reflect.Type) bool {
+	return t == bigIntType || t == bigFloatType || t == bigRatType
+}
+
+// bigToInt converts a *big.Int, *big.Float or *big.Rat to a *big.Int,
+// truncating towards zero like the conversion of a float to an int.
+func bigToInt(i interface{}) (*big.Int, error) {
+	switch v := i.(type) {
+	case *big.Int:
+		if v != nil {
+			return v, nil
+		}
+	case *big.Float:
+		if v != nil && !v.IsInf() {
+			n, _ := v.Int(nil)
+			return n, nil
+		}
+	case *big.Rat:
+		if v != nil {
+			return new(big.Int).Quo(v.Num(), v.Denom()), nil
+		}
+	}
+	return nil, fmt.Errorf("unable to cast %v of type %T to an integer", i, i)
+}
+
+// parseBigInt parses s as an integer in the given base, or else as a
+// decimal or fraction such as "12.5" or "3/4", which it truncates towards
+// zero like bigToInt.
+func parseBigInt(s string, base int) (*big.Int, bool) {
+	if n, ok := new(big.Int).SetString(s, base); ok {
+		return n, true
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, false
+	}
+	n, _ := bigToInt(r)
+	return n, true
+}
+
+// bigSign returns the sign of a non-nil big number.
+func bigSign(i interface{}) int {
+	switch v := i.(type) {
+	case *big.Int:
+		return v.Sign()
+	case *big.Float:
+		return v.Sign()
+	case *big.Rat:
+		return v.Sign()
+	}
+	return 0
+}
+
+// bigToInt64 converts a big number to an int64 that fits in a signed
+// integer of the given bit size.
+func bigToInt64(i interface{}, bitSize int) (int64, error) {
+	n, err := bigToInt(i)
+	if err != nil {
+		return 0, err
+	}
+	if n.IsInt64() {
+		if v := n.Int64(); bitSize == 64 || (v >= -1<<(bitSize-1) && v < 1<<(bitSize-1)) {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("unable to cast %v of type %T to int%d: %w", i, i, bitSize, ErrValueOutOfRange)
+}
+
+// bigToUint64 converts a big number to a uint64 that fits in an unsigned
+// integer of the given bit size.
+func bigToUint64(i interface{}, bitSize int) (uint64, error) {
+	n, err := bigToInt(i)
+	if err != nil {
+		return 0, err
+	}
+	if bigSign(i) < 0 {
+		return 0, fmt.Errorf("unable to cast %v of type %T to uint%d: %w", i, i, bitSize, ErrNegativeNotAllowed)
+	}
+	if n.IsUint64() {
+		if v := n.Uint64(); bitSize == 64 || v < 1<<bitSize {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("unable to cast %v of type %T to uint%d: %w", i, i, bitSize, ErrValueOutOfRange)
+}
+
+// bigToFloat converts a big number to the nearest float of the given bit
+// size. Finite values too large for it are out of range.
+func bigToFloat(i interface{}, bitSize int) (float64, error) {
+	var f *big.Float
+	switch v := i.(type) {
+	case *big.Int:
+		if v != nil {
+			f = new(big.Float).SetInt(v)
+		}
+	case *big.Float:
+		f = v
+	case *big.Rat:
+		if v != nil {
+			f = new(big.Float).SetRat(v)
+		}
+	}
+	if f == nil {
+		return 0, fmt.Errorf("unable to cast %v of type %T to float%d", i, i, bitSize)
+	}
+
+	var v float64
+	if bitSize == 32 {
+		f32, _ := f.Float32()
+		v = float64(f32)
+	} else {
+		v, _ = f.Float64()
+	}
+	if math.IsInf(v, 0) && !f.IsInf() {
+		return 0, fmt.Errorf("unable to cast %v of type %T to float%d: %w", i, i, bitSize, ErrValueOutOfRange)
+	}
+	return v, nil
+}
+
+// bigFloatPrec returns a precision in bits that holds a number written
+// with the given number of digits.
+func bigFloatPrec(digits int) uint {
+	if prec := uint(digits) * 4; prec > 64 {
+		return prec
+	}
+	return 64
 }
 
 func indirectToStringerOrError(a interface{}) interface{} {
@@ -1430,17 +3207,89 @@ func indirectToStringerOrError(a interface{}) interface{} {
 	}
 
 	v := reflect.ValueOf(a)
-	for !v.Type().Implements(reflect.TypeOf((*error)(nil)).Elem()) &&
-		!v.Type().Implements(reflect.TypeOf((*fmt.Stringer)(nil)).Elem()) &&
-		v.Kind() == reflect.Ptr && !v.IsNil() {
+	for !isStringerOrError(v.Type()) && v.Kind() == reflect.Ptr && !v.IsNil() {
 		v = v.Elem()
 	}
-	return v.Interface()
+	// A type's own string form wins over its database value, so that an
+	// enum with String and Value methods casts to its name.
+	if isStringerOrError(v.Type()) {
+		return v.Interface()
+	}
+	return driverValue(v.Interface())
+}
+
+func isStringerOrError(t reflect.Type) bool {
+	return t.Implements(reflect.TypeOf((*error)(nil)).Elem()) ||
+		t.Implements(reflect.TypeOf((*fmt.Stringer)(nil)).Elem()) ||
+		t.Implements(textMarshalerType)
+}
+
+// JSONOptions controls how the map casts decode JSON strings. The slice
+// casts always decode numbers as json.Number.
+type JSONOptions struct {
+	// UseNumber decodes numbers into interface{} values as json.Number
+	// instead of float64, so that large integers such as IDs keep their
+	// precision through ToInt64E and the like.
+	UseNumber bool
+	// AllowTrailingData ignores anything that follows the JSON document.
+	// Otherwise decoding fails unless only white space follows it, as
+	// json.Unmarshal does.
+	AllowTrailingData bool
+}
+
+var jsonOptions atomic.Pointer[JSONOptions]
+
+// SetJSONOptions sets how the casts of the package functions decode JSON
+// strings. The default is the zero JSONOptions, which decodes like
+// json.Unmarshal. A Caster has its own JSONOptions.
+func SetJSONOptions(options JSONOptions) {
+	jsonOptions.Store(&options)
 }
 
 // jsonStringToObject attempts to unmarshall a string as JSON into
-// the object passed as pointer.
-func jsonStringToObject(s string, v interface{}) error {
-	data := []byte(s)
-	return json.Unmarshal(data, v)
+// the object passed as pointer, with the JSONOptions of c.
+func (c Caster) jsonStringToObject(s string, v interface{}) error {
+	if c.JSONOptions != nil {
+		return decodeJSON(s, v, *c.JSONOptions)
+	}
+	return decodeJSON(s, v, JSONOptions{})
+}
+
+// decodeJSON decodes the JSON document s into v with the given options.
+func decodeJSON(s string, v interface{}, options JSONOptions) error {
+	d := json.NewDecoder(strings.NewReader(s))
+	if options.UseNumber {
+		d.UseNumber()
+	}
+	if err := d.Decode(v); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	if !options.AllowTrailingData {
+		if _, err := d.Token(); err != io.EOF {
+			return fmt.Errorf("invalid data after top-level value at offset %d", d.InputOffset())
+		}
+	}
+	return nil
+}
+
+// isJSONArray reports whether s looks like a JSON array.
+func isJSONArray(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]")
+}
+
+// jsonArrayElements decodes s if it is a JSON array, keeping numbers as
+// json.Number so that the typed slice casts convert them exactly.
+func jsonArrayElements(s string) ([]interface{}, bool) {
+	if !isJSONArray(s) {
+		return nil, false
+	}
+	var elems []interface{}
+	if decodeJSON(s, &elems, JSONOptions{UseNumber: true}) != nil {
+		return nil, false
+	}
+	return elems, true
 }
@@ -0,0 +1,372 @@
+package castlearn
+
+import (
+	"math/big"
+	"time"
+)
+
+// Caster casts values with options of its own rather than those set for
+// the package functions with SetNumberLocale, SetFloatFormat and the like,
+// so that packages sharing castlearn do not affect each other. Its methods
+// are the casts of the package functions. The zero Caster has the default
+// options:
+//
+//	c := castlearn.Caster{NumberLocale: castlearn.LocaleDE()}
+//	f, err := c.ToFloat64E("1.234,5") // 1234.5
+type Caster struct {
+	// FloatFormat formats floats in the casts to strings.
+	FloatFormat FloatFormat
+	// FloatPolicy controls the special float values NaN, ±Inf and -0.
+	FloatPolicy FloatPolicy
+	// NumberLocale, if set, is the locale numeric strings are written in.
+	NumberLocale *NumberLocale
+	// LenientNumbers, if set, relaxes the syntax of numeric strings.
+	LenientNumbers *LenientNumbers
+	// SplitOptions, if set, controls how the slice casts split strings,
+	// which are split at white space otherwise.
+	SplitOptions *SplitOptions
+	// MapDecoder, if set, decodes strings for the map casts, which detect
+	// the format with DetectMapDecoder otherwise.
+	MapDecoder MapDecoder
+	// JSONOptions, if set, controls how JSON strings are decoded, which is
+	// like json.Unmarshal otherwise.
+	JSONOptions *JSONOptions
+	// TimezoneAbbreviations, if set, resolves the zone abbreviations of
+	// inputs with a named timezone only, as SetTimezoneAbbreviations does.
+	// LoadTimezoneAbbreviations builds it from location names.
+	TimezoneAbbreviations map[string]*time.Location
+}
+
+// defaultCaster returns the Caster of the package functions, with the
+// options set with SetNumberLocale, SetFloatFormat and the like.
+func defaultCaster() Caster {
+	c := Caster{
+		NumberLocale:   numberLocale.Load(),
+		LenientNumbers: lenientNumbers.Load(),
+		SplitOptions:   splitOptions.Load(),
+		JSONOptions:    jsonOptions.Load(),
+	}
+	if f := floatFormat.Load(); f != nil {
+		c.FloatFormat = *f
+	}
+	if p := floatPolicy.Load(); p != nil {
+		c.FloatPolicy = *p
+	}
+	if d := mapDecoder.Load(); d != nil {
+		c.MapDecoder = *d
+	}
+	if l := timezoneAbbreviations.Load(); l != nil {
+		c.TimezoneAbbreviations = *l
+	}
+	return c
+}
+
+// ToBool casts an interface to a bool type.
+func (c Caster) ToBool(i interface{}) bool {
+	v, _ := c.ToBoolE(i)
+	return v
+}
+
+// ToTime casts an interface to a time.Time type.
+func (c Caster) ToTime(i interface{}) time.Time {
+	v, _ := c.ToTimeE(i)
+	return v
+}
+
+func (c Caster) ToTimeInDefaultLocation(i interface{}, location *time.Location) time.Time {
+	v, _ := c.ToTimeInDefaultLocationE(i, location)
+	return v
+}
+
+// ToUnix casts an interface to a Unix time in seconds.
+func (c Caster) ToUnix(i interface{}) int64 {
+	v, _ := c.ToUnixE(i)
+	return v
+}
+
+// ToUnixMilli casts an interface to a Unix time in milliseconds.
+func (c Caster) ToUnixMilli(i interface{}) int64 {
+	v, _ := c.ToUnixMilliE(i)
+	return v
+}
+
+// ToUnixNano casts an interface to a Unix time in nanoseconds.
+func (c Caster) ToUnixNano(i interface{}) int64 {
+	v, _ := c.ToUnixNanoE(i)
+	return v
+}
+
+// ToTimeString casts an interface to a time.Time and formats it with the
+// given layout.
+func (c Caster) ToTimeString(i interface{}, layout string) string {
+	v, _ := c.ToTimeStringE(i, layout)
+	return v
+}
+
+// ToTimeStringInDefaultLocation casts an interface to a time.Time and
+// formats it with the given layout, interpreting inputs without a timezone
+// to be in the given location.
+func (c Caster) ToTimeStringInDefaultLocation(i interface{}, layout string, location *time.Location) string {
+	v, _ := c.ToTimeStringInDefaultLocationE(i, layout, location)
+	return v
+}
+
+// ToDate casts an interface to a Date type.
+func (c Caster) ToDate(i interface{}) Date {
+	v, _ := c.ToDateE(i)
+	return v
+}
+
+// ToLocation casts an interface to a *time.Location type.
+func (c Caster) ToLocation(i interface{}) *time.Location {
+	v, _ := c.ToLocationE(i)
+	return v
+}
+
+// ToDuration casts an interface to a time.Duration type.
+func (c Caster) ToDuration(i interface{}) time.Duration {
+	v, _ := c.ToDurationE(i)
+	return v
+}
+
+// ToFloat64 casts an interface to a float64 type.
+func (c Caster) ToFloat64(i interface{}) float64 {
+	v, _ := c.ToFloat64E(i)
+	return v
+}
+
+// ToFloat32 casts an interface to a float32 type.
+func (c Caster) ToFloat32(i interface{}) float32 {
+	v, _ := c.ToFloat32E(i)
+	return v
+}
+
+// ToComplex128 casts an interface to a complex128 type.
+func (c Caster) ToComplex128(i interface{}) complex128 {
+	v, _ := c.ToComplex128E(i)
+	return v
+}
+
+// ToComplex64 casts an interface to a complex64 type.
+func (c Caster) ToComplex64(i interface{}) complex64 {
+	v, _ := c.ToComplex64E(i)
+	return v
+}
+
+// ToRatio casts an interface to a fraction.
+func (c Caster) ToRatio(i interface{}) float64 {
+	v, _ := c.ToRatioE(i)
+	return v
+}
+
+// ToPercent casts an interface holding a percentage to a fraction.
+func (c Caster) ToPercent(i interface{}) float64 {
+	v, _ := c.ToPercentE(i)
+	return v
+}
+
+// ToUnitRatio casts an interface to a fraction in [0, 1].
+func (c Caster) ToUnitRatio(i interface{}) float64 {
+	v, _ := c.ToUnitRatioE(i)
+	return v
+}
+
+// ToInt64 casts an interface to an int64 type.
+func (c Caster) ToInt64(i interface{}) int64 {
+	v, _ := c.ToInt64E(i)
+	return v
+}
+
+// ToInt32 casts an interface to an int32 type.
+func (c Caster) ToInt32(i interface{}) int32 {
+	v, _ := c.ToInt32E(i)
+	return v
+}
+
+// ToInt16 casts an interface to an int16 type.
+func (c Caster) ToInt16(i interface{}) int16 {
+	v, _ := c.ToInt16E(i)
+	return v
+}
+
+// ToInt8 casts an interface to an int8 type.
+func (c Caster) ToInt8(i interface{}) int8 {
+	v, _ := c.ToInt8E(i)
+	return v
+}
+
+// ToInt casts an interface to an int type.
+func (c Caster) ToInt(i interface{}) int {
+	v, _ := c.ToIntE(i)
+	return v
+}
+
+func (c Caster) ToUint(i interface{}) uint {
+	v, _ := c.ToUintE(i)
+	return v
+}
+
+// ToUint64 casts an interface to a uint64 type.
+func (c Caster) ToUint64(i interface{}) uint64 {
+	v, _ := c.ToUint64E(i)
+	return v
+}
+
+// ToUint32 casts an interface to a uint32 type.
+func (c Caster) ToUint32(i interface{}) uint32 {
+	v, _ := c.ToUint32E(i)
+	return v
+}
+
+// ToUint16 casts an interface to a uint16 type.
+func (c Caster) ToUint16(i interface{}) uint16 {
+	v, _ := c.ToUint16E(i)
+	return v
+}
+
+// ToUint8 casts an interface to a uint8 type.
+func (c Caster) ToUint8(i interface{}) uint8 {
+	v, _ := c.ToUint8E(i)
+	return v
+}
+
+// ToBigInt casts an interface to a *big.Int type.
+func (c Caster) ToBigInt(i interface{}) *big.Int {
+	v, _ := c.ToBigIntE(i)
+	return v
+}
+
+// ToBigFloat casts an interface to a *big.Float type.
+func (c Caster) ToBigFloat(i interface{}) *big.Float {
+	v, _ := c.ToBigFloatE(i)
+	return v
+}
+
+// ToBigRat casts an interface to a *big.Rat type.
+func (c Caster) ToBigRat(i interface{}) *big.Rat {
+	v, _ := c.ToBigRatE(i)
+	return v
+}
+
+// ToDecimal casts an interface to a Decimal type.
+func (c Caster) ToDecimal(i interface{}) Decimal {
+	v, _ := c.ToDecimalE(i)
+	return v
+}
+
+// ToDecimalWithScale casts an interface to a Decimal type with the given
+// scale.
+func (c Caster) ToDecimalWithScale(i interface{}, scale int32, mode RoundingMode) Decimal {
+	v, _ := c.ToDecimalWithScaleE(i, scale, mode)
+	return v
+}
+
+// ToString casts an interface to a string type.
+func (c Caster) ToString(i interface{}) string {
+	v, _ := c.ToStringE(i)
+	return v
+}
+
+// ToStringWithFormat casts an interface to a string type, formatting floats
+// with the given FloatFormat.
+func (c Caster) ToStringWithFormat(i interface{}, format FloatFormat) string {
+	v, _ := c.ToStringWithFormatE(i, format)
+	return v
+}
+
+// ToStringMapString casts an interface to a map[string]string type.
+func (c Caster) ToStringMapString(i interface{}) map[string]string {
+	v, _ := c.ToStringMapStringE(i)
+	return v
+}
+
+// ToStringMapStringSlice casts an interface to a map[string][]string type.
+func (c Caster) ToStringMapStringSlice(i interface{}) map[string][]string {
+	v, _ := c.ToStringMapStringSliceE(i)
+	return v
+}
+
+// ToStringMapBool casts an interface to a map[string]bool type.
+func (c Caster) ToStringMapBool(i interface{}) map[string]bool {
+	v, _ := c.ToStringMapBoolE(i)
+	return v
+}
+
+// ToStringMapInt casts an interface to a map[string]int type.
+func (c Caster) ToStringMapInt(i interface{}) map[string]int {
+	v, _ := c.ToStringMapIntE(i)
+	return v
+}
+
+// ToStringMapInt64 casts an interface to a map[string]int64 type.
+func (c Caster) ToStringMapInt64(i interface{}) map[string]int64 {
+	v, _ := c.ToStringMapInt64E(i)
+	return v
+}
+
+// ToStringMap casts an interface to a map[string]interface{} type.
+func (c Caster) ToStringMap(i interface{}) map[string]interface{} {
+	v, _ := c.ToStringMapE(i)
+	return v
+}
+
+// ToStringMapDeep casts an interface to a map[string]interface{} type,
+// converting nested maps and slices too.
+func (c Caster) ToStringMapDeep(i interface{}) map[string]interface{} {
+	v, _ := c.ToStringMapDeepE(i)
+	return v
+}
+
+// ToSlice casts an interface to a []interface{} type.
+func (c Caster) ToSlice(i interface{}) []interface{} {
+	v, _ := c.ToSliceE(i)
+	return v
+}
+
+// ToBoolSlice casts an interface to a []bool type.
+func (c Caster) ToBoolSlice(i interface{}) []bool {
+	v, _ := c.ToBoolSliceE(i)
+	return v
+}
+
+// ToStringSlice casts an interface to a []string type.
+func (c Caster) ToStringSlice(i interface{}) []string {
+	v, _ := c.ToStringSliceE(i)
+	return v
+}
+
+// ToIntSlice casts an interface to a []int type.
+func (c Caster) ToIntSlice(i interface{}) []int {
+	v, _ := c.ToIntSliceE(i)
+	return v
+}
+
+// ToDurationSlice casts an interface to a []time.Duration type.
+func (c Caster) ToDurationSlice(i interface{}) []time.Duration {
+	v, _ := c.ToDurationSliceE(i)
+	return v
+}
+
+// ToSliceOfStringMap casts an interface to a []map[string]interface{} type.
+func (c Caster) ToSliceOfStringMap(i interface{}) []map[string]interface{} {
+	v, _ := c.ToSliceOfStringMapE(i)
+	return v
+}
+
+// ToSliceOfStringMapString casts an interface to a []map[string]string type.
+func (c Caster) ToSliceOfStringMapString(i interface{}) []map[string]string {
+	v, _ := c.ToSliceOfStringMapStringE(i)
+	return v
+}
+
+// ToStringMapSlice casts an interface to a map[string][]interface{} type.
+func (c Caster) ToStringMapSlice(i interface{}) map[string][]interface{} {
+	v, _ := c.ToStringMapSliceE(i)
+	return v
+}
+
+// ToStringMapIntSlice casts an interface to a map[string][]int type.
+func (c Caster) ToStringMapIntSlice(i interface{}) map[string][]int {
+	v, _ := c.ToStringMapIntSliceE(i)
+	return v
+}
@@ -0,0 +1,70 @@
+package castlearn
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaster(t *testing.T) {
+	t.Parallel()
+
+	abbreviations, err := LoadTimezoneAbbreviations(map[string]string{"CET": "Europe/Berlin"})
+	require.NoError(t, err)
+
+	c := Caster{
+		FloatPolicy:           FloatPolicy{RejectNonFinite: true, NaN: "null"},
+		NumberLocale:          LocaleDE(),
+		LenientNumbers:        &LenientNumbers{Suffixes: []string{"%"}},
+		SplitOptions:          &SplitOptions{Separator: ";", TrimSpace: true},
+		MapDecoder:            DecodeLabelsMap,
+		TimezoneAbbreviations: abbreviations,
+	}
+
+	assert.Equal(t, 1234.5, c.ToFloat64("1.234,5"))
+	assert.Equal(t, 12, c.ToInt(" 12 % "))
+	assert.Equal(t, []int{1, 2}, c.ToIntSlice("1; 2"))
+	assert.Equal(t, []string{"a b", "c"}, c.ToStringSlice("a b;c"))
+	assert.Equal(t, map[string]string{"a": "1&b=2"}, c.ToStringMapString("a=1&b=2"))
+	assert.Equal(t,
+		map[string]interface{}{"id": json.Number("9007199254740993")},
+		Caster{JSONOptions: &JSONOptions{UseNumber: true}}.ToStringMap(`{"id": 9007199254740993}`))
+	assert.Equal(t, "null", c.ToString(math.NaN()))
+	_, err = c.ToFloat64E(math.Inf(1))
+	assert.Error(t, err)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2006, 1, 2, 15, 4, 5, 0, berlin), c.ToTime("Mon, 02 Jan 2006 15:04:05 CET"))
+
+	v, err := ToWithCasterE[port]("8.080", c)
+	assert.NoError(t, err)
+	assert.Equal(t, port(8080), v)
+
+	var workers int
+	assert.NoError(t, c.NewScanner(&workers).Scan("1.000"))
+	assert.Equal(t, 1000, workers)
+
+	var config struct {
+		Ratio float64 `env:"RATIO"`
+	}
+	err = EnvBinder{Lookup: envLookup(map[string]string{"RATIO": "0,5"}), Caster: &c}.Bind(&config)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, config.Ratio)
+
+	// The package functions keep their defaults.
+	_, err = ToFloat64E("1.234,5")
+	assert.Error(t, err)
+	assert.Equal(t, []string{"a", "b;c"}, ToStringSlice("a b;c"))
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, ToStringMapString("a=1&b=2"))
+	assert.Equal(t, "NaN", ToString(math.NaN()))
+
+	// The zero Caster has the defaults, whatever the package options.
+	assert.Equal(t, []string{"a", "b;c"}, Caster{}.ToStringSlice("a b;c"))
+	_, err = Caster{}.ToFloat64E("1.234,5")
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package castlearn
+
+import (
+	"fmt"
+	"time"
+)
+
+// Date is a civil date without a time of day or timezone, such as a
+// birthdate or a billing day.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the Date on which t occurs in its own location.
+func DateOf(t time.Time) Date {
+	var d Date
+	d.Year, d.Month, d.Day = t.Date()
+	return d
+}
+
+// String returns the date in the ISO 8601 format, e.g. "2006-01-02".
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// IsZero reports whether d is the zero Date.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// In returns the time.Time at the start of d in the given location.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It
+// accepts the same inputs as ToDateE.
+func (d *Date) UnmarshalText(text []byte) error {
+	v, err := ToDateE(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
@@ -0,0 +1,249 @@
+package castlearn
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode determines how a Decimal is rounded to a smaller scale.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest neighbour, and ties away from zero.
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds to the nearest neighbour, and ties to the even
+	// neighbour (banker's rounding).
+	RoundHalfEven
+	// RoundDown rounds towards zero.
+	RoundDown
+	// RoundUp rounds away from zero.
+	RoundUp
+	// RoundFloor rounds towards negative infinity.
+	RoundFloor
+	// RoundCeiling rounds towards positive infinity.
+	RoundCeiling
+)
+
+// Decimal is an exact decimal number with the value unscaled × 10^-scale,
+// e.g. 19.99 is 1999 with a scale of 2. The zero value is 0.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+// NewDecimal returns the Decimal unscaled × 10^-scale.
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	return Decimal{unscaled: big.NewInt(unscaled), scale: scale}
+}
+
+// maxDecimalScale bounds the scale of parsed decimals, so that an exponent
+// such as the one of "1e999999999" cannot make String or Round allocate
+// gigabytes.
+const maxDecimalScale = 1 << 15
+
+// ParseDecimal parses a decimal number such as "19.99", "-.5" or "1.5e-3".
+// Its scale, the number of digits after the decimal point, must be within
+// ±32768.
+func ParseDecimal(s string) (Decimal, error) {
+	mantissa, exp := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		if exp, err = strconv.ParseInt(s[i+1:], 10, 32); err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		mantissa = s[:i]
+	}
+
+	digits := mantissa
+	if len(digits) > 0 && (digits[0] == '+' || digits[0] == '-') {
+		digits = digits[1:]
+	}
+	intPart, fracPart := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		intPart, fracPart = digits[:i], digits[i+1:]
+	}
+	if intPart+fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	unscaled, _ := new(big.Int).SetString(intPart+fracPart, 10)
+	if mantissa[0] == '-' {
+		unscaled.Neg(unscaled)
+	}
+	scale := int64(len(fracPart)) - exp
+	if scale > maxDecimalScale || scale < -maxDecimalScale {
+		return Decimal{}, fmt.Errorf("invalid decimal %q: exponent out of range", s)
+	}
+	return Decimal{unscaled: unscaled, scale: int32(scale)}, nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Unscaled returns the unscaled value of d.
+func (d Decimal) Unscaled() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(d.unscaled)
+}
+
+// Scale returns the number of digits after the decimal point of d.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of d.
+func (d Decimal) Sign() int {
+	if d.unscaled == nil {
+		return 0
+	}
+	return d.unscaled.Sign()
+}
+
+// Cmp compares d and e and returns -1, 0 or +1 if d is less than, equal to
+// or greater than e. Decimals with different scales can be equal.
+func (d Decimal) Cmp(e Decimal) int {
+	return d.Rat().Cmp(e.Rat())
+}
+
+// Rat returns d as a *big.Rat.
+func (d Decimal) Rat() *big.Rat {
+	r := new(big.Rat).SetInt(d.Unscaled())
+	if d.scale > 0 {
+		return r.Quo(r, new(big.Rat).SetInt(pow10(int64(d.scale))))
+	}
+	return r.Mul(r, new(big.Rat).SetInt(pow10(-int64(d.scale))))
+}
+
+// Float64 returns the float64 nearest to d.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// Round returns d rounded to the given scale using the given rounding
+// mode. A larger scale pads d with trailing zeros.
+func (d Decimal) Round(scale int32, mode RoundingMode) Decimal {
+	if scale >= d.scale {
+		unscaled := d.Unscaled()
+		return Decimal{unscaled: unscaled.Mul(unscaled, pow10(int64(scale-d.scale))), scale: scale}
+	}
+	return roundRat(d.Rat(), scale, mode)
+}
+
+// String returns d in plain decimal notation, never using an exponent.
+func (d Decimal) String() string {
+	digits := d.Unscaled().String()
+	neg := digits[0] == '-'
+	if neg {
+		digits = digits[1:]
+	}
+
+	switch {
+	case digits == "0" && d.scale <= 0:
+		// Trailing zeros would only repeat the zero.
+	case d.scale < 0:
+		digits += strings.Repeat("0", int(-d.scale))
+	case d.scale > 0:
+		if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		digits = digits[:len(digits)-int(d.scale)] + "." + digits[len(digits)-int(d.scale):]
+	}
+
+	if neg {
+		return "-" + digits
+	}
+	return digits
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	v, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// decimalFromRat returns r as a Decimal if it has a finite decimal
+// expansion, that is if its denominator has no prime factors but 2 and 5.
+func decimalFromRat(r *big.Rat) (Decimal, bool) {
+	den := new(big.Int).Set(r.Denom())
+	var twos, fives int32
+	two, five := big.NewInt(2), big.NewInt(5)
+	m := new(big.Int)
+	for {
+		if q, rem := new(big.Int).QuoRem(den, two, m); rem.Sign() == 0 {
+			den, twos = q, twos+1
+			continue
+		}
+		if q, rem := new(big.Int).QuoRem(den, five, m); rem.Sign() == 0 {
+			den, fives = q, fives+1
+			continue
+		}
+		break
+	}
+	if den.Cmp(big.NewInt(1)) != 0 {
+		return Decimal{}, false
+	}
+
+	scale := twos
+	if fives > scale {
+		scale = fives
+	}
+	return roundRat(r, scale, RoundDown), true
+}
+
+// roundRat returns r rounded to a Decimal with the given scale.
+func roundRat(r *big.Rat, scale int32, mode RoundingMode) Decimal {
+	num, den := new(big.Int).Set(r.Num()), new(big.Int).Set(r.Denom())
+	if scale >= 0 {
+		num.Mul(num, pow10(int64(scale)))
+	} else {
+		den.Mul(den, pow10(-int64(scale)))
+	}
+
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() != 0 {
+		sign := num.Sign()
+		half := rem.Abs(rem).Lsh(rem, 1).Cmp(den) // compares |rem| with den/2
+
+		var away bool
+		switch mode {
+		case RoundHalfUp:
+			away = half >= 0
+		case RoundHalfEven:
+			away = half > 0 || (half == 0 && q.Bit(0) == 1)
+		case RoundUp:
+			away = true
+		case RoundFloor:
+			away = sign < 0
+		case RoundCeiling:
+			away = sign > 0
+		}
+		if away {
+			q.Add(q, big.NewInt(int64(sign)))
+		}
+	}
+	return Decimal{unscaled: q, scale: scale}
+}
+
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
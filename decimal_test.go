@@ -0,0 +1,103 @@
+package castlearn
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect string
+		scale  int32
+		iserr  bool
+	}{
+		{"19.99", "19.99", 2, false},
+		{"-0.5", "-0.5", 1, false},
+		{"+1", "1", 0, false},
+		{".5", "0.5", 1, false},
+		{"5.", "5", 0, false},
+		{"0.000", "0.000", 3, false},
+		{"1.5e-3", "0.0015", 4, false},
+		{"1E3", "1000", -3, false},
+		{"123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789", 9, false},
+		{"0e3", "0", -3, false},
+		{"-0.0e1", "0", 0, false},
+		{"1e32768", "1" + strings.Repeat("0", 32768), -32768, false},
+		{"1e-32768", "0." + strings.Repeat("0", 32767) + "1", 32768, false},
+		// errors
+		{"", "", 0, true},
+		{".", "", 0, true},
+		{"-", "", 0, true},
+		{"1.2.3", "", 0, true},
+		{"1e", "", 0, true},
+		{"1,5", "", 0, true},
+		{"NaN", "", 0, true},
+		{"1e32769", "", 0, true},
+		{"1e-32769", "", 0, true},
+		{"1e999999999", "", 0, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := ParseDecimal(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v.String(), errmsg)
+		assert.Equal(t, test.scale, v.Scale(), errmsg)
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	tests := []struct {
+		input  string
+		scale  int32
+		mode   RoundingMode
+		expect string
+	}{
+		{"2.345", 2, RoundHalfUp, "2.35"},
+		{"-2.345", 2, RoundHalfUp, "-2.35"},
+		{"2.345", 2, RoundHalfEven, "2.34"},
+		{"2.355", 2, RoundHalfEven, "2.36"},
+		{"2.3451", 2, RoundHalfEven, "2.35"},
+		{"2.349", 2, RoundDown, "2.34"},
+		{"-2.349", 2, RoundDown, "-2.34"},
+		{"2.341", 2, RoundUp, "2.35"},
+		{"-2.341", 2, RoundUp, "-2.35"},
+		{"-2.341", 2, RoundFloor, "-2.35"},
+		{"2.349", 2, RoundFloor, "2.34"},
+		{"2.341", 2, RoundCeiling, "2.35"},
+		{"-2.349", 2, RoundCeiling, "-2.34"},
+		{"2.5", 0, RoundHalfEven, "2"},
+		{"0.004", 2, RoundHalfUp, "0.00"},
+		{"1250", -2, RoundHalfUp, "1300"},
+		{"19.9", 3, RoundHalfUp, "19.900"},
+		{"1e-3", -2, RoundHalfUp, "0"},
+		{"0.004", -1, RoundDown, "0"},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		d, err := ParseDecimal(test.input)
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, d.Round(test.scale, test.mode).String(), errmsg)
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	assert.Equal(t, 0, NewDecimal(150, 2).Cmp(NewDecimal(15, 1)))
+	assert.Equal(t, -1, NewDecimal(-1, 0).Cmp(Decimal{}))
+	assert.Equal(t, 1, NewDecimal(1, -2).Cmp(NewDecimal(99, 0)))
+	assert.Equal(t, 0, Decimal{}.Sign())
+	assert.Equal(t, "0", Decimal{}.String())
+	assert.Equal(t, 19.99, NewDecimal(1999, 2).Float64())
+}
@@ -0,0 +1,245 @@
+package castlearn
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// ErrEnvNotSet is wrapped by the errors of BindEnv for required variables
+// that are not set.
+var ErrEnvNotSet = errors.New("required environment variable not set")
+
+// EnvBinder populates structs from environment variables. The fields are
+// described with struct tags:
+//
+//	type Config struct {
+//		Port    int               `env:"PORT" envDefault:"8080"`
+//		Timeout time.Duration     `env:"TIMEOUT,required"`
+//		Hosts   []string          `env:"HOSTS" envSeparator:";"`
+//		Labels  map[string]string `env:"LABELS" envKeyValSeparator:"="`
+//		DB      DBConfig          `envPrefix:"DB_"`
+//	}
+//
+// Values are cast to the field types with the rules of ToE. Slices are
+// split at envSeparator, "," by default, and maps are split into entries
+// the same way, with keys and values separated by envKeyValSeparator, ":"
+// by default. Elements are trimmed, but empty ones are kept. Fields without
+// an env tag that are structs, or pointers to structs, are bound
+// recursively, with the envPrefix tag prepended to the names of their
+// variables. A nil pointer to a struct is only allocated if one of its
+// variables is set, and a struct is not bound again inside itself, as in
+// the Next *Node field of a linked list Node.
+type EnvBinder struct {
+	// Prefix is prepended to the names of all variables.
+	Prefix string
+	// Lookup returns the value of a variable and whether it is set. It
+	// defaults to os.LookupEnv.
+	Lookup func(name string) (string, bool)
+	// Caster casts the values to the field types. It defaults to the
+	// options of the package functions.
+	Caster *Caster
+}
+
+// EnvErrors lists the problems EnvBinder.Bind found, one per variable.
+type EnvErrors []error
+
+func (e EnvErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors, like that of errors.Join.
+func (e EnvErrors) Unwrap() []error {
+	return e
+}
+
+// Is reports whether any of the errors matches target, so that
+// errors.Is(err, ErrEnvNotSet) works before Go 1.20 too.
+func (e EnvErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the errors that matches target, for errors.As.
+func (e EnvErrors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// BindEnv populates the struct v points to from the environment.
+func BindEnv(v interface{}) error {
+	return EnvBinder{}.Bind(v)
+}
+
+// Bind populates the struct v points to from the environment. It sets
+// every field it can and reports all missing and invalid variables at
+// once as EnvErrors.
+func (b EnvBinder) Bind(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unable to bind environment to %#v of type %T: not a pointer to a struct", v, v)
+	}
+	if b.Lookup == nil {
+		b.Lookup = os.LookupEnv
+	}
+	if b.Caster == nil {
+		c := defaultCaster()
+		b.Caster = &c
+	}
+
+	var errs EnvErrors
+	b.bindStruct(rv.Elem(), b.Prefix, map[reflect.Type]bool{}, &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// bindStruct binds the fields of the struct v and reports whether any of
+// its variables is set. The types of the structs being bound are in path,
+// so that fields such as the Next *Node of a Node are not bound endlessly.
+func (b EnvBinder) bindStruct(v reflect.Value, prefix string, path map[reflect.Type]bool, errs *EnvErrors) bool {
+	t := v.Type()
+	path[t] = true
+	defer delete(path, t)
+
+	var set bool
+	for i := 0; i < t.NumField(); i++ {
+		field, fv := t.Field(i), v.Field(i)
+		if !fv.CanSet() {
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup("env")
+		if !ok {
+			st := field.Type
+			if st.Kind() == reflect.Ptr {
+				st = st.Elem()
+			}
+			if st.Kind() != reflect.Struct || path[st] {
+				continue
+			}
+			structPrefix := prefix + field.Tag.Get("envPrefix")
+			switch {
+			case fv.Kind() == reflect.Struct:
+				set = b.bindStruct(fv, structPrefix, path, errs) || set
+			case !fv.IsNil():
+				set = b.bindStruct(fv.Elem(), structPrefix, path, errs) || set
+			default:
+				// A nil pointer stays nil, and its required variables
+				// are not required, unless one of its variables is set.
+				var structErrs EnvErrors
+				sv := reflect.New(st)
+				if b.bindStruct(sv.Elem(), structPrefix, path, &structErrs) {
+					fv.Set(sv)
+					*errs = append(*errs, structErrs...)
+					set = true
+				}
+			}
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		name = prefix + name
+		value, ok := b.Lookup(name)
+		if ok {
+			set = true
+		} else {
+			value, ok = field.Tag.Lookup("envDefault")
+		}
+		if !ok {
+			if opts == "required" {
+				*errs = append(*errs, fmt.Errorf("%s: %w", name, ErrEnvNotSet))
+			}
+			continue
+		}
+
+		if err := b.setEnvField(fv, field.Tag, value); err != nil {
+			*errs = append(*errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return set
+}
+
+// setEnvField casts the value of a variable to the field v.
+func (b EnvBinder) setEnvField(v reflect.Value, tag reflect.StructTag, value string) error {
+	// Slices such as net.IP decode themselves.
+	t := v.Type()
+	if reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return b.setValue(v, value)
+	}
+
+	split := SplitOptions{Separator: tag.Get("envSeparator"), TrimSpace: true, KeepEmpty: true}
+	if split.Separator == "" {
+		split.Separator = ","
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		parts, err := split.Split(value)
+		if err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(t, len(parts), len(parts))
+		for i, part := range parts {
+			if err := b.setValue(s.Index(i), part); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	case reflect.Map:
+		kvSep := tag.Get("envKeyValSeparator")
+		if kvSep == "" {
+			kvSep = ":"
+		}
+		entries, err := split.Split(value)
+		if err != nil {
+			return err
+		}
+		m := reflect.MakeMap(t)
+		for _, entry := range entries {
+			key, val, ok := strings.Cut(entry, kvSep)
+			if !ok {
+				return fmt.Errorf("invalid map entry %q: missing %q", entry, kvSep)
+			}
+			k := reflect.New(t.Key()).Elem()
+			if err := b.setValue(k, strings.TrimSpace(key)); err != nil {
+				return err
+			}
+			e := reflect.New(t.Elem()).Elem()
+			if err := b.setValue(e, strings.TrimSpace(val)); err != nil {
+				return err
+			}
+			m.SetMapIndex(k, e)
+		}
+		v.Set(m)
+		return nil
+	default:
+		return b.setValue(v, value)
+	}
+}
+
+// setValue casts s to the type of v and sets v to it.
+func (b EnvBinder) setValue(v reflect.Value, s string) error {
+	cast, err := b.Caster.toType(s, v.Type())
+	if err != nil {
+		return err
+	}
+	v.Set(cast)
+	return nil
+}
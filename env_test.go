@@ -0,0 +1,170 @@
+package castlearn
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type envDBConfig struct {
+	Host string `env:"HOST" envDefault:"localhost"`
+	Port port   `env:"PORT,required"`
+}
+
+type envConfig struct {
+	Name     string            `env:"NAME"`
+	Debug    bool              `env:"DEBUG"`
+	Workers  int               `env:"WORKERS" envDefault:"4"`
+	Timeout  time.Duration     `env:"TIMEOUT,required"`
+	Ratio    *float64          `env:"RATIO"`
+	Hosts    []string          `env:"HOSTS"`
+	Ports    []int             `env:"PORTS" envSeparator:";"`
+	Labels   map[string]string `env:"LABELS"`
+	Limits   map[string]int    `env:"LIMITS" envKeyValSeparator:"="`
+	IP       net.IP            `env:"IP"`
+	Since    Date              `env:"SINCE"`
+	DB       envDBConfig       `envPrefix:"DB_"`
+	Cache    *envDBConfig      `envPrefix:"CACHE_"`
+	Untagged string
+	internal string `env:"INTERNAL"`
+}
+
+func envLookup(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+}
+
+func TestBindEnv(t *testing.T) {
+	env := map[string]string{
+		"APP_NAME":       "api",
+		"APP_DEBUG":      "1",
+		"APP_TIMEOUT":    "30s",
+		"APP_RATIO":      "0.5",
+		"APP_HOSTS":      "a, b,c",
+		"APP_PORTS":      "80;443",
+		"APP_LABELS":     "env:prod,team:core",
+		"APP_LIMITS":     "cpu=2, memory=512",
+		"APP_IP":         "10.0.0.1",
+		"APP_SINCE":      "2006-01-02",
+		"APP_DB_PORT":    "5432",
+		"APP_CACHE_HOST": "cache",
+		"APP_CACHE_PORT": "6379",
+		"APP_INTERNAL":   "x",
+	}
+
+	var c envConfig
+	err := EnvBinder{Prefix: "APP_", Lookup: envLookup(env)}.Bind(&c)
+	assert.NoError(t, err)
+
+	ratio := 0.5
+	assert.Equal(t, envConfig{
+		Name:    "api",
+		Debug:   true,
+		Workers: 4,
+		Timeout: 30 * time.Second,
+		Ratio:   &ratio,
+		Hosts:   []string{"a", "b", "c"},
+		Ports:   []int{80, 443},
+		Labels:  map[string]string{"env": "prod", "team": "core"},
+		Limits:  map[string]int{"cpu": 2, "memory": 512},
+		IP:      net.IPv4(10, 0, 0, 1),
+		Since:   Date{2006, time.January, 2},
+		DB:      envDBConfig{Host: "localhost", Port: 5432},
+		Cache:   &envDBConfig{Host: "cache", Port: 6379},
+	}, c)
+}
+
+func TestBindEnvErrors(t *testing.T) {
+	env := map[string]string{
+		"WORKERS":    "many",
+		"PORTS":      "80;http",
+		"LABELS":     "env",
+		"CACHE_PORT": "6379",
+	}
+
+	var c envConfig
+	err := EnvBinder{Lookup: envLookup(env)}.Bind(&c)
+
+	var errs EnvErrors
+	if assert.True(t, errors.As(err, &errs)) {
+		assert.Len(t, errs, 5)
+		assert.True(t, errs.Is(ErrEnvNotSet))
+		assert.False(t, errs.Is(ErrCycle))
+	}
+	assert.True(t, errors.Is(err, ErrEnvNotSet))
+	assert.Contains(t, err.Error(), "WORKERS:")
+	assert.Contains(t, err.Error(), "PORTS:")
+	assert.Contains(t, err.Error(), "LABELS:")
+	assert.Contains(t, err.Error(), "TIMEOUT: required environment variable not set")
+	assert.Contains(t, err.Error(), "DB_PORT: required environment variable not set")
+	assert.Equal(t, port(6379), c.Cache.Port)
+
+	assert.Error(t, BindEnv(c))
+	assert.Error(t, BindEnv((*envConfig)(nil)))
+}
+
+type envNode struct {
+	Name string `env:"NAME"`
+	Next *envNode
+}
+
+type envSections struct {
+	Node  envNode
+	Cache *envDBConfig `envPrefix:"CACHE_"`
+	Queue *envDBConfig `envPrefix:"QUEUE_"`
+}
+
+func TestBindEnvEmptyElements(t *testing.T) {
+	var c struct {
+		Hosts  []string          `env:"HOSTS"`
+		Ports  []int             `env:"PORTS"`
+		Labels map[string]string `env:"LABELS"`
+		None   []string          `env:"NONE"`
+	}
+	env := map[string]string{
+		"HOSTS":  "a, ,b,",
+		"PORTS":  "80,,443",
+		"LABELS": "env:prod,",
+		"NONE":   "",
+	}
+
+	err := EnvBinder{Lookup: envLookup(env)}.Bind(&c)
+	assert.Equal(t, []string{"a", "", "b", ""}, c.Hosts)
+	assert.Equal(t, []string{}, c.None)
+	assert.Contains(t, err.Error(), "PORTS:")
+	assert.Contains(t, err.Error(), "LABELS:")
+}
+
+func TestBindEnvSections(t *testing.T) {
+	env := map[string]string{
+		"NAME":       "a",
+		"QUEUE_PORT": "5672",
+	}
+
+	var c envSections
+	assert.NoError(t, EnvBinder{Lookup: envLookup(env)}.Bind(&c))
+	assert.Equal(t, envNode{Name: "a"}, c.Node)
+	assert.Nil(t, c.Cache)
+	assert.Equal(t, &envDBConfig{Host: "localhost", Port: 5672}, c.Queue)
+
+	// The required port of a section only counts once the section is set.
+	env = map[string]string{"CACHE_HOST": "cache"}
+	err := EnvBinder{Lookup: envLookup(env)}.Bind(&c)
+	assert.True(t, errors.Is(err, ErrEnvNotSet))
+	assert.Contains(t, err.Error(), "CACHE_PORT:")
+}
+
+func TestBindEnvOS(t *testing.T) {
+	t.Setenv("CASTLEARN_TEST_TIMEOUT", "1m")
+	t.Setenv("CASTLEARN_TEST_DB_PORT", "1")
+	t.Setenv("CASTLEARN_TEST_CACHE_PORT", "2")
+
+	var c envConfig
+	assert.NoError(t, EnvBinder{Prefix: "CASTLEARN_TEST_"}.Bind(&c))
+	assert.Equal(t, time.Minute, c.Timeout)
+}
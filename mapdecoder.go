@@ -0,0 +1,183 @@
+package castlearn
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// MapDecoder decodes a string such as "a=1&b=2" into a map for the map
+// casts, which then cast its values like those of any other map.
+type MapDecoder func(s string) (map[string]interface{}, error)
+
+var mapDecoder atomic.Pointer[MapDecoder]
+
+// SetMapDecoder sets the decoder the map casts use for strings, such as
+// DecodeQueryMap or a decoder for another format like YAML. Passing nil
+// restores the default, which detects the format with DetectMapDecoder. A
+// Caster has its own MapDecoder.
+func SetMapDecoder(decoder MapDecoder) {
+	if decoder == nil {
+		mapDecoder.Store(nil)
+		return
+	}
+	mapDecoder.Store(&decoder)
+}
+
+// DetectMapDecoder returns the decoder for the format of s: DecodeJSONMap
+// if it starts with "{" or "[", DecodeDotenvMap for several lines,
+// DecodeQueryMap if it contains "&", DecodeLabelsMap if it contains ",",
+// "==" or "!=", DecodeQueryMap if it contains "=" and DecodeJSONMap
+// otherwise.
+func DetectMapDecoder(s string) MapDecoder {
+	if d := detectMapDecoder(s); d != nil {
+		return d
+	}
+	return DecodeJSONMap
+}
+
+// detectMapDecoder is DetectMapDecoder, except that it returns nil for
+// JSON, which the map casts decode into their result directly.
+func detectMapDecoder(s string) MapDecoder {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "" || strings.HasPrefix(s, "{") || strings.HasPrefix(s, "["):
+		return nil
+	case strings.Contains(s, "\n"):
+		return DecodeDotenvMap
+	case strings.Contains(s, "&"):
+		return DecodeQueryMap
+	case strings.Contains(s, ",") || strings.Contains(s, "==") || strings.Contains(s, "!="):
+		return DecodeLabelsMap
+	case strings.Contains(s, "="):
+		return DecodeQueryMap
+	}
+	return nil
+}
+
+// decodeMapString decodes s with the MapDecoder of c or the one detected
+// for s. It reports false if s is JSON, which the map casts decode with
+// the JSONOptions of c, and c has no MapDecoder or DecodeJSONMap.
+func (c Caster) decodeMapString(s string) (map[string]interface{}, bool, error) {
+	decode := c.MapDecoder
+	if decode == nil {
+		decode = detectMapDecoder(s)
+	} else if reflect.ValueOf(decode).Pointer() == reflect.ValueOf(DecodeJSONMap).Pointer() {
+		decode = nil
+	}
+	if decode == nil {
+		return nil, false, nil
+	}
+	m, err := decode(s)
+	if err != nil {
+		return nil, true, fmt.Errorf("unable to decode %q to a map: %w", s, err)
+	}
+	return m, true, nil
+}
+
+// decodeSingleValueMapString is decodeMapString for the map casts whose
+// values are not slices. A key that s gives several times, as in
+// "a=1&a=2", is an error rather than a value that fails to cast.
+func (c Caster) decodeSingleValueMapString(s string) (map[string]interface{}, bool, error) {
+	m, ok, err := c.decodeMapString(s)
+	if err != nil {
+		return nil, ok, err
+	}
+	for k, v := range m {
+		if values, isSlice := v.([]string); isSlice {
+			return nil, ok, fmt.Errorf("unable to decode %q to a map: key %q is given %d times", s, k, len(values))
+		}
+	}
+	return m, ok, nil
+}
+
+// DecodeJSONMap decodes a JSON object with the options set with
+// SetJSONOptions. As the MapDecoder of a Caster, it uses the JSONOptions
+// of the Caster.
+func DecodeJSONMap(s string) (map[string]interface{}, error) {
+	var m map[string]interface{}
+	if err := defaultCaster().jsonStringToObject(s, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DecodeQueryMap decodes a URL query string such as "a=1&b=x%20y". Keys
+// given once map to a string, and keys given several times to a []string,
+// which the map casts to single values such as ToStringMapStringE reject.
+func DecodeQueryMap(s string) (map[string]interface{}, error) {
+	values, err := url.ParseQuery(strings.TrimPrefix(strings.TrimSpace(s), "?"))
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		if len(v) == 1 {
+			m[k] = v[0]
+		} else {
+			m[k] = v
+		}
+	}
+	return m, nil
+}
+
+// DecodeLabelsMap decodes a label selector of equality requirements such
+// as "env=prod,tier==web".
+func DecodeLabelsMap(s string) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	for _, label := range strings.Split(s, ",") {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(label, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" || strings.HasSuffix(k, "!") {
+			return nil, fmt.Errorf("invalid label %q", label)
+		}
+		m[k] = strings.TrimSpace(strings.TrimPrefix(v, "="))
+	}
+	return m, nil
+}
+
+// DecodeDotenvMap decodes KEY=VALUE lines as in .env files. Empty lines and
+// comments starting with "#" are skipped, as is an "export " before a key.
+// Values may be in single quotes, taken literally, or in double quotes,
+// with Go escapes such as "\n". Unquoted values end at " #".
+func DecodeDotenvMap(s string) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		k, v, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("line %d: invalid line %q", n, line)
+		}
+
+		v = strings.TrimSpace(v)
+		switch {
+		case len(v) >= 2 && v[0] == '"' && v[len(v)-1] == '"':
+			unquoted, err := strconv.Unquote(v)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid value %s", n, v)
+			}
+			v = unquoted
+		case len(v) >= 2 && v[0] == '\'' && v[len(v)-1] == '\'':
+			v = v[1 : len(v)-1]
+		default:
+			if i := strings.Index(v, " #"); i >= 0 {
+				v = strings.TrimSpace(v[:i])
+			}
+		}
+		m[k] = v
+	}
+	return m, scanner.Err()
+}
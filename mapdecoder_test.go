@@ -0,0 +1,90 @@
+package castlearn
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapDecoders(t *testing.T) {
+	tests := []struct {
+		decoder MapDecoder
+		input   string
+		expect  map[string]interface{}
+		iserr   bool
+	}{
+		{DecodeJSONMap, `{"a": 1}`, map[string]interface{}{"a": float64(1)}, false},
+		{DecodeQueryMap, "a=1&b=x%20y", map[string]interface{}{"a": "1", "b": "x y"}, false},
+		{DecodeQueryMap, "?a=1&a=2", map[string]interface{}{"a": []string{"1", "2"}}, false},
+		{DecodeLabelsMap, "env=prod, tier==web", map[string]interface{}{"env": "prod", "tier": "web"}, false},
+		{DecodeLabelsMap, "env=", map[string]interface{}{"env": ""}, false},
+		{DecodeDotenvMap, "# comment\nA=1\n\nexport B = two # note\nC=\"x\\ny\"\nD='$HOME'\n", map[string]interface{}{"A": "1", "B": "two", "C": "x\ny", "D": "$HOME"}, false},
+		// errors
+		{DecodeJSONMap, "a=1", nil, true},
+		{DecodeQueryMap, "a=%zz", nil, true},
+		{DecodeLabelsMap, "env!=prod", nil, true},
+		{DecodeLabelsMap, "env", nil, true},
+		{DecodeDotenvMap, "A=1\nB", nil, true},
+		{DecodeDotenvMap, `A="x\q"`, nil, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := test.decoder(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+}
+
+func TestMapCastDecoding(t *testing.T) {
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, ToStringMapString("a=1&b=2"))
+	assert.Equal(t, map[string]int{"cpu": 2, "memory": 512}, ToStringMapInt("cpu=2,memory=512"))
+	assert.Equal(t, map[string]int64{"a": 1}, ToStringMapInt64("a=1"))
+	assert.Equal(t, map[string]bool{"debug": true, "trace": false}, ToStringMapBool("debug=true\ntrace=0"))
+	assert.Equal(t, map[string]interface{}{"a": "1"}, ToStringMap("a=1"))
+	assert.Equal(t, map[string][]string{"a": {"1", "2"}, "b": {"3"}}, ToStringMapStringSlice("a=1&a=2&b=3"))
+	assert.Equal(t, map[string]int{"a": 1}, ToStringMapInt(`{"a": 1}`))
+
+	_, err := ToStringMapStringE("env!=prod")
+	assert.Error(t, err)
+
+	// Repeated keys only cast to slices.
+	_, err = ToStringMapStringE("a=1&a=2")
+	assert.EqualError(t, err, `unable to decode "a=1&a=2" to a map: key "a" is given 2 times`)
+	_, err = ToStringMapIntE("a=1&b=2&a=3")
+	assert.Error(t, err)
+	_, err = ToStringMapBoolE("a=true&a=false")
+	assert.Error(t, err)
+	assert.Equal(t, map[string]interface{}{"a": []string{"1", "2"}}, ToStringMap("a=1&a=2"))
+	_, err = ToStringMapStringE("not a map")
+	assert.Error(t, err)
+
+	// JSON arrays and malformed JSON get JSON errors.
+	for _, s := range []string{"[1,2]", `{"a":1,}`, ` [{"a":1}, {"b":2}]`} {
+		_, err = ToStringMapE(s)
+		if assert.Error(t, err, s) {
+			assert.NotContains(t, err.Error(), "invalid label", s)
+		}
+		assert.Nil(t, detectMapDecoder(s), s)
+	}
+
+	// DecodeJSONMap uses the JSONOptions of its Caster.
+	c := Caster{MapDecoder: DecodeJSONMap, JSONOptions: &JSONOptions{UseNumber: true}}
+	assert.Equal(t, map[string]interface{}{"id": json.Number("9007199254740993")}, c.ToStringMap(`{"id": 9007199254740993}`))
+	_, err = c.ToStringMapE("a=1")
+	assert.Error(t, err)
+
+	SetMapDecoder(DecodeLabelsMap)
+	defer SetMapDecoder(nil)
+	assert.Equal(t, map[string]string{"a": "1&b=2"}, ToStringMapString("a=1&b=2"))
+	_, err = ToStringMapE(`{"a": 1}`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,356 @@
+package castlearn
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// NumberLocale describes how numbers are written in a locale, e.g.
+// "1,234,567.89" in English or "1.234.567,89" in German.
+type NumberLocale struct {
+	// GroupSeparators are the characters accepted between digit groups of
+	// the integer part.
+	GroupSeparators string
+	// DecimalSeparator separates the integer part from the fraction.
+	DecimalSeparator rune
+	// GroupSizes are the number of digits per group, starting from the
+	// decimal separator. The last size repeats, so {3} describes 1,234,567
+	// and {3, 2} the Indian 12,34,567.
+	GroupSizes []int
+}
+
+// LocaleEN returns the English number locale, as in "1,234,567.89".
+func LocaleEN() *NumberLocale {
+	return &NumberLocale{GroupSeparators: ",", DecimalSeparator: '.', GroupSizes: []int{3}}
+}
+
+// LocaleDE returns the German number locale, as in "1.234.567,89".
+func LocaleDE() *NumberLocale {
+	return &NumberLocale{GroupSeparators: ".", DecimalSeparator: ',', GroupSizes: []int{3}}
+}
+
+// LocaleFR returns the French number locale, as in "1 234 567,89". Spaces,
+// no-break spaces and narrow no-break spaces all separate groups.
+func LocaleFR() *NumberLocale {
+	return &NumberLocale{GroupSeparators: " \u00a0\u202f", DecimalSeparator: ',', GroupSizes: []int{3}}
+}
+
+// LocaleIN returns the Indian number locale, as in "12,34,567.89".
+func LocaleIN() *NumberLocale {
+	return &NumberLocale{GroupSeparators: ",", DecimalSeparator: '.', GroupSizes: []int{3, 2}}
+}
+
+// LenientNumbers relaxes the syntax of numeric strings: white space around
+// the number is ignored, underscores may separate digits of any number, and
+// the given unit suffixes are stripped.
+type LenientNumbers struct {
+	// Suffixes are units that may follow a number, such as "%", "px" or
+	// "ms". They are matched case-insensitively.
+	Suffixes []string
+}
+
+var (
+	errInvalidGrouping   = errors.New("invalid digit grouping")
+	errInvalidUnderscore = errors.New("invalid underscore")
+)
+
+// FloatPolicy controls how the casts treat the special IEEE 754 values
+// NaN, ±Inf and -0. The zero value keeps them as they are.
+type FloatPolicy struct {
+	// RejectNonFinite makes the float casts fail for NaN and ±Inf. The int
+	// and uint casts always fail for them.
+	RejectNonFinite bool
+	// NormalizeNegativeZero makes the float casts and ToStringE turn -0
+	// into 0.
+	NormalizeNegativeZero bool
+	// NaN, PosInf and NegInf are the strings ToStringE formats the special
+	// values as, e.g. "null" for JSON output. Empty strings mean "NaN",
+	// "+Inf" and "-Inf".
+	NaN, PosInf, NegInf string
+}
+
+var (
+	numberLocale   atomic.Pointer[NumberLocale]
+	lenientNumbers atomic.Pointer[LenientNumbers]
+	floatPolicy    atomic.Pointer[FloatPolicy]
+)
+
+// SetFloatPolicy sets the policy for special float values of the package
+// functions. A Caster has its own.
+func SetFloatPolicy(policy FloatPolicy) {
+	floatPolicy.Store(&policy)
+}
+
+func isFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// applyFloatPolicy checks the result f of a float cast of i against the
+// float policy of c.
+func (c Caster) applyFloatPolicy(i interface{}, f float64, typ string) (float64, error) {
+	p := c.FloatPolicy
+	if p.RejectNonFinite && !isFinite(f) {
+		return 0, fmt.Errorf("unable to cast %#v of type %T to %s: %w", i, i, typ, ErrNotFinite)
+	}
+	if p.NormalizeNegativeZero && f == 0 {
+		return 0, nil
+	}
+	return f, nil
+}
+
+// FloatFormat controls how ToStringE formats floats. The zero FloatFormat
+// formats like strconv.FormatFloat(f, 'f', -1, bitSize), with as many
+// digits as needed to represent the value exactly and no exponent.
+type FloatFormat struct {
+	// Verb is the strconv.FormatFloat format, e.g. 'f', 'e' or 'g'.
+	Verb byte
+	// Precision is the strconv.FormatFloat precision, used if Verb is set.
+	// -1 gives the fewest digits that represent the value exactly.
+	Precision int
+	// TrimZeros strips trailing zeros from the fraction, e.g. "1.50" becomes
+	// "1.5" and "2.00" becomes "2".
+	TrimZeros bool
+}
+
+var floatFormat atomic.Pointer[FloatFormat]
+
+// SetFloatFormat sets the FloatFormat used by ToStringE and the casts to
+// strings built on it. A Caster has its own.
+func SetFloatFormat(format FloatFormat) {
+	floatFormat.Store(&format)
+}
+
+// formatFloat formats f for ToStringE according to the float format and
+// policy of c.
+func (c Caster) formatFloat(f float64, bitSize int) string {
+	p, format := c.FloatPolicy, c.FloatFormat
+	switch {
+	case math.IsNaN(f) && p.NaN != "":
+		return p.NaN
+	case math.IsInf(f, 1) && p.PosInf != "":
+		return p.PosInf
+	case math.IsInf(f, -1) && p.NegInf != "":
+		return p.NegInf
+	case f == 0 && p.NormalizeNegativeZero:
+		f = 0
+	}
+
+	verb, prec := format.verb()
+	s := strconv.FormatFloat(f, verb, prec, bitSize)
+	if format.TrimZeros && isFinite(f) {
+		s = trimZeros(s)
+	}
+	return s
+}
+
+// formatComplex formats x for ToStringE like strconv.FormatComplex, e.g.
+// "(1+2i)", with the verb and precision of the float format of c.
+func (c Caster) formatComplex(x complex128, bitSize int) string {
+	verb, prec := c.FloatFormat.verb()
+	s := strconv.FormatComplex(x, verb, prec, bitSize)
+	if !c.FloatFormat.TrimZeros {
+		return s
+	}
+	// Trim the real and imaginary parts separately, keeping the sign of the
+	// imaginary part and its exponent.
+	inner := s[1 : len(s)-2]
+	split := strings.LastIndexAny(inner, "+-")
+	for split > 0 && (inner[split-1] == 'e' || inner[split-1] == 'E') {
+		split = strings.LastIndexAny(inner[:split], "+-")
+	}
+	if split <= 0 {
+		return s
+	}
+	return "(" + trimZeros(inner[:split]) + inner[split:split+1] + trimZeros(inner[split+1:]) + "i)"
+}
+
+func (f FloatFormat) verb() (byte, int) {
+	if f.Verb == 0 {
+		return 'f', -1
+	}
+	return f.Verb, f.Precision
+}
+
+// trimZeros strips the trailing zeros of the fraction of a formatted float,
+// keeping its exponent.
+func trimZeros(s string) string {
+	mantissa, exp := s, ""
+	if i := strings.IndexAny(s, "eEpP"); i >= 0 {
+		mantissa, exp = s[:i], s[i:]
+	}
+	if strings.IndexByte(mantissa, '.') < 0 {
+		return s
+	}
+	mantissa = strings.TrimRight(mantissa, "0")
+	mantissa = strings.TrimSuffix(mantissa, ".")
+	return mantissa + exp
+}
+
+// SetLenientNumbers makes the int, uint and float casts of the package
+// functions parse strings with the given leniency. Passing nil restores the
+// default strict parsing. A Caster has its own leniency.
+func SetLenientNumbers(lenient *LenientNumbers) {
+	if lenient == nil {
+		lenientNumbers.Store(nil)
+		return
+	}
+	l := LenientNumbers{Suffixes: append([]string(nil), lenient.Suffixes...)}
+	lenientNumbers.Store(&l)
+}
+
+// SetNumberLocale makes the int, uint and float casts of the package
+// functions parse strings as written in the given locale. Passing nil
+// restores the default, which only accepts the Go syntax understood by the
+// strconv package. A Caster has its own locale.
+func SetNumberLocale(locale *NumberLocale) {
+	if locale == nil {
+		numberLocale.Store(nil)
+		return
+	}
+	l := *locale
+	l.GroupSizes = append([]int(nil), locale.GroupSizes...)
+	numberLocale.Store(&l)
+}
+
+// normalize rewrites a number written in the locale into the Go syntax,
+// removing the group separators and replacing the decimal separator.
+func (l *NumberLocale) normalize(s string) (string, error) {
+	intPart, fracPart, hasFrac := s, "", false
+	if i := strings.IndexRune(s, l.DecimalSeparator); i >= 0 {
+		intPart, fracPart, hasFrac = s[:i], s[i+utf8.RuneLen(l.DecimalSeparator):], true
+	}
+	if strings.ContainsAny(fracPart, l.GroupSeparators) {
+		return "", errInvalidGrouping
+	}
+
+	if strings.ContainsAny(intPart, l.GroupSeparators) {
+		sign := ""
+		if len(intPart) > 0 && (intPart[0] == '+' || intPart[0] == '-') {
+			sign, intPart = intPart[:1], intPart[1:]
+		}
+		digits, ok := l.ungroup(intPart)
+		if !ok {
+			return "", errInvalidGrouping
+		}
+		intPart = sign + digits
+	}
+
+	if !hasFrac {
+		return intPart, nil
+	}
+	return intPart + "." + fracPart, nil
+}
+
+// ungroup removes the group separators from the integer part s, and
+// reports whether its digits are grouped as the locale prescribes.
+func (l *NumberLocale) ungroup(s string) (string, bool) {
+	var groups []string
+	start := 0
+	for i, r := range s {
+		if strings.ContainsRune(l.GroupSeparators, r) {
+			groups = append(groups, s[start:i])
+			start = i + utf8.RuneLen(r)
+		}
+	}
+	groups = append(groups, s[start:])
+
+	for i := range groups {
+		n := len(groups[i])
+		if n == 0 {
+			return "", false
+		}
+		if len(l.GroupSizes) == 0 {
+			continue
+		}
+		pos := len(groups) - 1 - i
+		if pos >= len(l.GroupSizes) {
+			pos = len(l.GroupSizes) - 1
+		}
+		if size := l.GroupSizes[pos]; n > size || (i > 0 && n != size) {
+			return "", false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
+
+// normalize strips the white space, unit suffix and underscores the
+// leniency allows from s.
+func (l *LenientNumbers) normalize(s string) (string, error) {
+	s = strings.TrimSpace(s)
+
+	suffix := ""
+	for _, u := range l.Suffixes {
+		if len(u) > len(suffix) && len(u) < len(s) && strings.EqualFold(s[len(s)-len(u):], u) {
+			suffix = u
+		}
+	}
+	s = strings.TrimSpace(s[:len(s)-len(suffix)])
+
+	if !strings.Contains(s, "_") {
+		return s, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '_' {
+			// Like in Go literals, an underscore must separate two digits,
+			// or a base prefix and a digit.
+			if i == 0 || i == len(s)-1 || !isAlphanumeric(s[i-1]) || !isAlphanumeric(s[i+1]) {
+				return "", errInvalidUnderscore
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String(), nil
+}
+
+func isAlphanumeric(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// normalizeNumber prepares a numeric string for the strconv and math/big
+// parsers according to the leniency and number locale of c.
+func (c Caster) normalizeNumber(s string) (string, error) {
+	if c.LenientNumbers != nil {
+		var err error
+		if s, err = c.LenientNumbers.normalize(s); err != nil {
+			return "", err
+		}
+	}
+	if c.NumberLocale != nil {
+		return c.NumberLocale.normalize(s)
+	}
+	return s, nil
+}
+
+// parseInt is strconv.ParseInt with base 0 for the casts of strings.
+func (c Caster) parseInt(s string, bitSize int) (int64, error) {
+	s, err := c.normalizeNumber(s)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(s, 0, bitSize)
+}
+
+// parseUint is strconv.ParseUint with base 0 for the casts of strings.
+func (c Caster) parseUint(s string, bitSize int) (uint64, error) {
+	s, err := c.normalizeNumber(s)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(s, 0, bitSize)
+}
+
+// parseFloat is strconv.ParseFloat for the casts of strings.
+func (c Caster) parseFloat(s string, bitSize int) (float64, error) {
+	s, err := c.normalizeNumber(s)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(s, bitSize)
+}
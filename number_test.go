@@ -0,0 +1,210 @@
+package castlearn
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumberLocale(t *testing.T) {
+	defer SetNumberLocale(nil)
+
+	tests := []struct {
+		locale *NumberLocale
+		input  string
+		expect float64
+		iserr  bool
+	}{
+		{LocaleEN(), "1,234,567", 1234567, false},
+		{LocaleEN(), "1,234.56", 1234.56, false},
+		{LocaleEN(), "-1,234", -1234, false},
+		{LocaleEN(), "1234.5", 1234.5, false},
+		{LocaleDE(), "1.234,56", 1234.56, false},
+		{LocaleDE(), "1.234.567", 1234567, false},
+		{LocaleDE(), "0,5", 0.5, false},
+		{LocaleFR(), "1 234,56", 1234.56, false},
+		{LocaleFR(), "1 234 567,8", 1234567.8, false},
+		{LocaleIN(), "12,34,567.89", 1234567.89, false},
+		{LocaleIN(), "1,00,00,000", 10000000, false},
+		{LocaleIN(), "999", 999, false},
+		// errors
+		{LocaleEN(), "1,23", 0, true},
+		{LocaleEN(), "1,2345", 0, true},
+		{LocaleEN(), ",123", 0, true},
+		{LocaleEN(), "1,,234", 0, true},
+		{LocaleEN(), "1.234,5", 0, true},
+		{LocaleDE(), "1.5", 0, true},
+		{LocaleDE(), "1,234.5", 0, true},
+		{LocaleIN(), "1,234,567", 0, true},
+		{nil, "1,234", 0, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		SetNumberLocale(test.locale)
+		v, err := ToFloat64E(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+
+	// The locale applies to all int, uint and float casts.
+	SetNumberLocale(LocaleDE())
+	assert.Equal(t, 1234567, ToInt("1.234.567"))
+	assert.Equal(t, int64(-1234), ToInt64("-1.234"))
+	assert.Equal(t, uint32(1234), ToUint32("1.234"))
+	assert.Equal(t, float32(1234.5), ToFloat32("1.234,5"))
+	assert.Equal(t, "123456789012345678901", ToString(ToBigInt("123.456.789.012.345.678.901")))
+	assert.Equal(t, "1234.56", ToString(ToDecimal("1.234,56")))
+	_, err := ToIntE("1.234,5")
+	assert.Error(t, err)
+
+	// The caller's locale is copied.
+	custom := &NumberLocale{GroupSeparators: "'", DecimalSeparator: '.', GroupSizes: []int{3}}
+	SetNumberLocale(custom)
+	custom.GroupSeparators = "_"
+	assert.Equal(t, 1234567.5, ToFloat64("1'234'567.5"))
+}
+
+func TestLenientNumbers(t *testing.T) {
+	SetLenientNumbers(&LenientNumbers{Suffixes: []string{"%", "px", "ms"}})
+	defer SetLenientNumbers(nil)
+
+	tests := []struct {
+		input  string
+		expect float64
+		iserr  bool
+	}{
+		{" 42 ", 42, false},
+		{"\t1e3\n", 1000, false},
+		{"1_000", 1000, false},
+		{"1_000.000_5", 1000.0005, false},
+		{"42px", 42, false},
+		{"42 PX", 42, false},
+		{"-7.5%", -7.5, false},
+		{"250ms", 250, false},
+		// errors
+		{"_1000", 0, true},
+		{"1000_", 0, true},
+		{"1__000", 0, true},
+		{"1_.5", 0, true},
+		{"42em", 0, true},
+		{"px", 0, true},
+		{"42px%", 0, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := ToFloat64E(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+
+	// The leniency applies to all int, uint and float casts.
+	assert.Equal(t, 42, ToInt(" 42 "))
+	assert.Equal(t, int64(42), ToInt64("42px"))
+	assert.Equal(t, uint8(200), ToUint8("2_00 ms"))
+	assert.Equal(t, float32(1000), ToFloat32("1e3 "))
+	assert.Equal(t, 0x1f, ToInt("0x_1f"))
+
+	// Leniency combines with the number locale.
+	SetNumberLocale(LocaleDE())
+	defer SetNumberLocale(nil)
+	assert.Equal(t, 1234.5, ToFloat64(" 1.234,5 % "))
+
+	// Without leniency the strict strconv syntax applies.
+	SetLenientNumbers(nil)
+	SetNumberLocale(nil)
+	_, err := ToIntE(" 42 ")
+	assert.Error(t, err)
+	_, err = ToFloat64E("1e3 ")
+	assert.Error(t, err)
+	_, err = ToInt64E("42px")
+	assert.Error(t, err)
+}
+
+func TestFloatPolicy(t *testing.T) {
+	defer SetFloatPolicy(FloatPolicy{})
+
+	// By default special values pass through the float casts.
+	assert.True(t, math.IsNaN(ToFloat64("NaN")))
+	assert.True(t, math.IsInf(ToFloat64("Inf"), 1))
+	assert.True(t, math.Signbit(ToFloat64(math.Copysign(0, -1))))
+	assert.Equal(t, "NaN", ToString(math.NaN()))
+	assert.Equal(t, "+Inf", ToString(math.Inf(1)))
+	assert.Equal(t, "-0", ToString(math.Copysign(0, -1)))
+
+	SetFloatPolicy(FloatPolicy{RejectNonFinite: true, NormalizeNegativeZero: true})
+	for i, input := range []interface{}{"NaN", "Inf", "-Inf", math.NaN(), float32(math.Inf(-1))} {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		_, err := ToFloat64E(input)
+		assert.True(t, errors.Is(err, ErrNotFinite), errmsg)
+		_, err = ToFloat32E(input)
+		assert.True(t, errors.Is(err, ErrNotFinite), errmsg)
+	}
+	assert.Equal(t, 1.5, ToFloat64("1.5"))
+	assert.False(t, math.Signbit(ToFloat64(math.Copysign(0, -1))))
+	assert.False(t, math.Signbit(float64(ToFloat32("-0"))))
+	assert.Equal(t, "0", ToString(math.Copysign(0, -1)))
+
+	SetFloatPolicy(FloatPolicy{NaN: "null", PosInf: "null", NegInf: "null"})
+	assert.Equal(t, "null", ToString(math.NaN()))
+	assert.Equal(t, "null", ToString(math.Inf(1)))
+	assert.Equal(t, "null", ToString(float32(math.Inf(-1))))
+	assert.Equal(t, "1.5", ToString(1.5))
+}
+
+func TestFloatFormat(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		format FloatFormat
+		expect string
+	}{
+		{1e21, FloatFormat{}, "1000000000000000000000"},
+		{1e21, FloatFormat{Verb: 'e', Precision: -1}, "1e+21"},
+		{1.5e-7, FloatFormat{Verb: 'g', Precision: -1}, "1.5e-07"},
+		{2.5, FloatFormat{Verb: 'f', Precision: 2}, "2.50"},
+		{2.5, FloatFormat{Verb: 'f', Precision: 2, TrimZeros: true}, "2.5"},
+		{float32(2), FloatFormat{Verb: 'f', Precision: 3, TrimZeros: true}, "2"},
+		{1200.0, FloatFormat{Verb: 'e', Precision: 4, TrimZeros: true}, "1.2e+03"},
+		{100.0, FloatFormat{TrimZeros: true}, "100"},
+		{math.Inf(1), FloatFormat{Verb: 'f', Precision: 2, TrimZeros: true}, "+Inf"},
+		{"1.500", FloatFormat{Verb: 'f', Precision: 1}, "1.500"},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i)
+
+		v, err := ToStringWithFormatE(test.input, test.format)
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+
+		assert.Equal(t, test.expect, Caster{FloatFormat: test.format}.ToString(test.input), errmsg)
+	}
+
+	c := Caster{FloatFormat: FloatFormat{Verb: 'f', Precision: 2}}
+	assert.Equal(t, []string{"1.00", "2.50"}, c.ToStringSlice([]interface{}{1.0, 2.5}))
+	assert.Equal(t, map[string]string{"a": "0.10"}, c.ToStringMapString(map[string]interface{}{"a": 0.1}))
+	assert.Equal(t, map[string][]string{"a": {"0.33"}}, c.ToStringMapStringSlice(map[string]interface{}{"a": []interface{}{1.0 / 3}}))
+
+	SetFloatFormat(FloatFormat{Verb: 'e', Precision: 1})
+	defer SetFloatFormat(FloatFormat{})
+	assert.Equal(t, "1.5e+00", ToString(1.5))
+	assert.Equal(t, []string{"2.0e+00"}, ToStringSlice([]interface{}{2.0}))
+	assert.Equal(t, "1.5", Caster{}.ToString(1.5))
+}
@@ -0,0 +1,134 @@
+package castlearn
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SplitOptions controls how the slice casts split strings into elements.
+// The zero SplitOptions splits at white space like strings.Fields.
+type SplitOptions struct {
+	// Separator separates the elements, e.g. "," or ";". An empty
+	// Separator splits at runs of white space.
+	Separator string
+	// TrimSpace trims white space around the elements.
+	TrimSpace bool
+	// Quotes allows elements in double quotes, which may contain the
+	// separator, as in CSV. A doubled quote inside quotes is a quote, and
+	// quoted elements are neither trimmed nor dropped if empty.
+	Quotes bool
+	// KeepEmpty keeps empty elements, such as the middle one of "a,,b",
+	// which are dropped otherwise.
+	KeepEmpty bool
+}
+
+var splitOptions atomic.Pointer[SplitOptions]
+
+var errUnterminatedQuote = errors.New("unterminated quote")
+
+// SetSplitOptions sets how ToSliceE, ToStringSliceE, ToIntSliceE,
+// ToBoolSliceE and ToDurationSliceE split strings. Passing nil restores
+// splitting at white space. A Caster has its own SplitOptions.
+func SetSplitOptions(options *SplitOptions) {
+	if options == nil {
+		splitOptions.Store(nil)
+		return
+	}
+	o := *options
+	splitOptions.Store(&o)
+}
+
+// splitString splits s with the SplitOptions of c.
+func (c Caster) splitString(s string) ([]string, error) {
+	if c.SplitOptions != nil {
+		return c.SplitOptions.Split(s)
+	}
+	return strings.Fields(s), nil
+}
+
+// Split splits s into elements according to the options. An empty s has no
+// elements.
+func (o SplitOptions) Split(s string) ([]string, error) {
+	if !o.Quotes {
+		if o.Separator == "" {
+			return strings.Fields(s), nil
+		}
+		if s == "" {
+			return []string{}, nil
+		}
+		return o.clean(strings.Split(s, o.Separator), nil), nil
+	}
+
+	var (
+		parts  []string
+		quoted []bool
+		b      strings.Builder
+		// inQuotes is set inside quotes, and wasQuoted once the current
+		// element has been quoted.
+		inQuotes, wasQuoted bool
+	)
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case inQuotes && r == '"':
+			if strings.HasPrefix(s[i+1:], `"`) {
+				b.WriteByte('"')
+				size++
+			} else {
+				inQuotes = false
+			}
+		case inQuotes:
+			b.WriteRune(r)
+		case r == '"' && !wasQuoted && strings.TrimSpace(b.String()) == "":
+			b.Reset()
+			inQuotes, wasQuoted = true, true
+		case o.Separator == "" && unicode.IsSpace(r), o.Separator != "" && strings.HasPrefix(s[i:], o.Separator):
+			if o.Separator != "" {
+				size = len(o.Separator)
+			}
+			parts, quoted = append(parts, b.String()), append(quoted, wasQuoted)
+			b.Reset()
+			wasQuoted = false
+		case wasQuoted && unicode.IsSpace(r):
+			// Skip white space after the closing quote.
+		default:
+			b.WriteRune(r)
+		}
+		i += size
+	}
+	if inQuotes {
+		return nil, errUnterminatedQuote
+	}
+	if s != "" {
+		parts, quoted = append(parts, b.String()), append(quoted, wasQuoted)
+	}
+
+	if o.Separator == "" {
+		// Runs of white space separate the elements.
+		o.KeepEmpty = false
+	}
+	return o.clean(parts, quoted), nil
+}
+
+// clean trims and drops the unquoted elements of parts as the options
+// require.
+func (o SplitOptions) clean(parts []string, quoted []bool) []string {
+	a := make([]string, 0, len(parts))
+	for i, part := range parts {
+		if quoted != nil && quoted[i] {
+			a = append(a, part)
+			continue
+		}
+		if o.TrimSpace {
+			part = strings.TrimSpace(part)
+		}
+		if part == "" && !o.KeepEmpty {
+			continue
+		}
+		a = append(a, part)
+	}
+	return a
+}
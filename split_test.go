@@ -0,0 +1,63 @@
+package castlearn
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitOptions(t *testing.T) {
+	tests := []struct {
+		input   string
+		options SplitOptions
+		expect  []string
+		iserr   bool
+	}{
+		{" a  b\tc ", SplitOptions{}, []string{"a", "b", "c"}, false},
+		{"", SplitOptions{Separator: ","}, []string{}, false},
+		{"a,b,,c", SplitOptions{Separator: ","}, []string{"a", "b", "c"}, false},
+		{"a,b,,c", SplitOptions{Separator: ",", KeepEmpty: true}, []string{"a", "b", "", "c"}, false},
+		{"a, b ,c", SplitOptions{Separator: ","}, []string{"a", " b ", "c"}, false},
+		{"a, b , ,c", SplitOptions{Separator: ",", TrimSpace: true}, []string{"a", "b", "c"}, false},
+		{"a::b::c", SplitOptions{Separator: "::"}, []string{"a", "b", "c"}, false},
+		{`a,"b,c",d`, SplitOptions{Separator: ",", Quotes: true}, []string{"a", "b,c", "d"}, false},
+		{`a, "b ""x"" " ,""`, SplitOptions{Separator: ",", Quotes: true, TrimSpace: true}, []string{"a", `b "x" `, ""}, false},
+		{`a ab"c d"`, SplitOptions{Quotes: true}, []string{"a", `ab"c`, `d"`}, false},
+		{`a  "b c"  d`, SplitOptions{Quotes: true}, []string{"a", "b c", "d"}, false},
+		{`a,"b`, SplitOptions{Separator: ",", Quotes: true}, nil, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := test.options.Split(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+}
+
+func TestSetSplitOptions(t *testing.T) {
+	assert.Equal(t, []string{"a,b"}, ToStringSlice("a,b"))
+	assert.Equal(t, []int{1, 2}, ToIntSlice("1 2"))
+
+	SetSplitOptions(&SplitOptions{Separator: ",", TrimSpace: true, Quotes: true})
+	defer SetSplitOptions(nil)
+
+	assert.Equal(t, []string{"a", "b c", "d,e"}, ToStringSlice(`a, b c,"d,e"`))
+	assert.Equal(t, []interface{}{"a", "b"}, ToSlice("a,b"))
+	assert.Equal(t, []int{1, 2, 3}, ToIntSlice("1, 2,3"))
+	assert.Equal(t, []bool{true, false}, ToBoolSlice("true,0"))
+	assert.Equal(t, []time.Duration{time.Second, time.Minute}, ToDurationSlice("1s,1m"))
+
+	_, err := ToIntSliceE("1,x")
+	assert.Error(t, err)
+	_, err = ToStringSliceE(`"a`)
+	assert.Error(t, err)
+}
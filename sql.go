@@ -0,0 +1,58 @@
+package castlearn
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Scanner is a sql.Scanner that scans a column into the value its
+// destination points to with the cast rules of ToE, so that a column of
+// any type can be scanned into a bool, int, string, time.Time or the like.
+// A NULL column scans into the zero value, which is nil for pointers.
+// Text and binary columns can also be scanned into byte slices such as
+// []byte and json.RawMessage, which receive a copy of the driver's buffer.
+//
+//	var port int
+//	err := row.Scan(castlearn.NewScanner(&port))
+type Scanner struct {
+	dest   reflect.Value
+	caster *Caster
+}
+
+// NewScanner returns a Scanner into dest, which must be a non-nil pointer.
+func NewScanner(dest interface{}) *Scanner {
+	return &Scanner{dest: reflect.ValueOf(dest)}
+}
+
+// NewScanner returns a Scanner into dest, which must be a non-nil pointer,
+// that casts with the options of c.
+func (c Caster) NewScanner(dest interface{}) *Scanner {
+	return &Scanner{dest: reflect.ValueOf(dest), caster: &c}
+}
+
+// Scan implements the sql.Scanner interface.
+func (s *Scanner) Scan(src interface{}) error {
+	if s.dest.Kind() != reflect.Ptr || s.dest.IsNil() {
+		return errors.New("castlearn: Scanner destination must be a non-nil pointer")
+	}
+	elem := s.dest.Elem()
+	if src == nil {
+		elem.Set(reflect.Zero(elem.Type()))
+		return nil
+	}
+	if b, ok := src.([]byte); ok {
+		// The driver may reuse the buffer after Scan returns.
+		src = append([]byte{}, b...)
+	}
+	c := s.caster
+	if c == nil {
+		d := defaultCaster()
+		c = &d
+	}
+	v, err := c.toType(src, elem.Type())
+	if err != nil {
+		return err
+	}
+	elem.Set(v)
+	return nil
+}
@@ -0,0 +1,111 @@
+package castlearn
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDriverValuer(t *testing.T) {
+	assert.Equal(t, "foo", ToString(sql.NullString{String: "foo", Valid: true}))
+	assert.Equal(t, "", ToString(sql.NullString{String: "foo"}))
+	assert.Equal(t, "", ToString(&sql.NullString{String: "foo"}))
+	assert.Equal(t, 8, ToInt(sql.NullInt64{Int64: 8, Valid: true}))
+	assert.Equal(t, 0, ToInt(sql.NullInt64{Int64: 8}))
+	assert.Equal(t, 8.5, ToFloat64(&sql.NullFloat64{Float64: 8.5, Valid: true}))
+	assert.Equal(t, true, ToBool(sql.NullBool{Bool: true, Valid: true}))
+	assert.Equal(t, int16(8), ToInt16(sql.NullInt16{Int16: 8, Valid: true}))
+
+	now := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	assert.Equal(t, now, ToTime(sql.NullTime{Time: now, Valid: true}))
+	_, err := ToTimeE(sql.NullTime{Time: now})
+	assert.Error(t, err)
+
+	var nilValuer *sql.NullString
+	assert.Equal(t, "", ToString(nilValuer))
+
+	// String takes precedence over Value.
+	s := statusActive
+	assert.Equal(t, "active", ToString(s))
+	assert.Equal(t, "active", ToString(&s))
+	assert.Equal(t, 1, ToInt(s))
+}
+
+type status int
+
+const statusActive status = 1
+
+func (s status) String() string {
+	if s == statusActive {
+		return "active"
+	}
+	return "inactive"
+}
+
+func (s status) Value() (driver.Value, error) {
+	return int64(s), nil
+}
+
+func TestScanner(t *testing.T) {
+	var (
+		port    int
+		enabled bool
+		name    string
+		created time.Time
+		timeout time.Duration
+		limit   *int
+		lvl     level
+	)
+
+	tests := []struct {
+		dest   interface{}
+		src    driver.Value
+		expect interface{}
+	}{
+		{&port, int64(8080), 8080},
+		{&port, "8080", 8080},
+		{&enabled, int64(1), true},
+		{&enabled, "true", true},
+		{&name, int64(42), "42"},
+		{&name, []byte("foo"), "foo"},
+		{&created, "2006-01-02", time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{&timeout, "1m", time.Minute},
+		{&lvl, "debug", level("debug")},
+		{&port, nil, 0},
+		{&name, nil, ""},
+		{&limit, nil, (*int)(nil)},
+	}
+
+	for _, test := range tests {
+		assert.NoError(t, NewScanner(test.dest).Scan(test.src))
+		assert.Equal(t, test.expect, reflect.ValueOf(test.dest).Elem().Interface())
+	}
+
+	assert.NoError(t, NewScanner(&limit).Scan(int64(10)))
+	if assert.NotNil(t, limit) {
+		assert.Equal(t, 10, *limit)
+	}
+
+	// Byte slices do not keep the driver's buffer.
+	var (
+		data []byte
+		raw  json.RawMessage
+	)
+	buf := []byte(`{"a":1}`)
+	assert.NoError(t, NewScanner(&data).Scan(buf))
+	assert.NoError(t, NewScanner(&raw).Scan(buf))
+	copy(buf, "xxxxxxx")
+	assert.Equal(t, []byte(`{"a":1}`), data)
+	assert.Equal(t, json.RawMessage(`{"a":1}`), raw)
+	assert.NoError(t, NewScanner(&raw).Scan(`[1]`))
+	assert.Equal(t, json.RawMessage(`[1]`), raw)
+
+	assert.Error(t, NewScanner(&port).Scan("foo"))
+	assert.Error(t, NewScanner(port).Scan(int64(1)))
+	assert.Error(t, NewScanner(nil).Scan(int64(1)))
+}